@@ -14,7 +14,6 @@ import (
 	"github.com/joefazee/learning-go-shop/internal/config"
 	"github.com/joefazee/learning-go-shop/internal/database"
 	"github.com/joefazee/learning-go-shop/internal/events"
-	"github.com/joefazee/learning-go-shop/internal/interfaces"
 	"github.com/joefazee/learning-go-shop/internal/logger"
 	"github.com/joefazee/learning-go-shop/internal/providers"
 	"github.com/joefazee/learning-go-shop/internal/repositories"
@@ -78,19 +77,22 @@ func main() {
 		userRepo,
 		cartRepo,
 	)
-	productService := services.NewProductService(db)
-	userService := services.NewUserService(db)
-	cartService := services.NewCartService(db)
-	orderService := services.NewOrderService(db)
-
-	var uploadProvider interfaces.UploadProvider
-	if cfg.Upload.UploadProvider == "s3" {
-		uploadProvider = providers.NewS3Provider(cfg)
-	} else {
-		uploadProvider = providers.NewLocalUploadProvider(cfg.Upload.Path)
-	}
+	uploadProvider, privateUploadProvider := providers.NewUploadProviders(cfg)
 
-	uploadService := services.NewUploadService(uploadProvider)
+	productService := services.NewProductService(db, cfg, uploadProvider)
+	userService := services.NewUserService(db)
+	cartService := services.NewCartService(db, cfg, uploadProvider)
+
+	uploadService := services.NewUploadService(db, cfg, uploadProvider, privateUploadProvider)
+	downloadService := services.NewDownloadService(db, cfg, privateUploadProvider)
+	orderService := services.NewOrderService(db, cfg, eventPublisher, downloadService, uploadProvider)
+	reviewService := services.NewReviewService(db, eventPublisher)
+	trashService := services.NewTrashService(db, cfg, uploadProvider)
+	revisionService := services.NewRevisionService(db)
+	currencyService := services.NewCurrencyService(db, cfg)
+	translationService := services.NewTranslationService(db, cfg)
+	warehouseService := services.NewWarehouseService(db, cfg, eventPublisher)
+	stockSubscriptionService := services.NewStockSubscriptionService(db)
 
 	srv := server.New(cfg,
 		&log,
@@ -99,7 +101,15 @@ func main() {
 		userService,
 		uploadService,
 		cartService,
-		orderService)
+		orderService,
+		downloadService,
+		reviewService,
+		trashService,
+		revisionService,
+		currencyService,
+		translationService,
+		warehouseService,
+		stockSubscriptionService)
 
 	router := srv.SetupRoutes()
 
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-aws/sqs"
@@ -39,6 +40,20 @@ func main() {
 
 	emailNotifier := notifications.NewEmailNotifier(emailConfig)
 
+	lowStock := &lowStockAlerts{
+		notifier:   emailNotifier,
+		recipients: cfg.LowStock.Recipients,
+	}
+
+	// In digest mode low-stock alerts are collected and emailed together
+	var digestTicks <-chan time.Time
+	if cfg.LowStock.Digest {
+		lowStock.digest = notifications.NewLowStockDigest()
+		ticker := time.NewTicker(cfg.LowStock.DigestInterval)
+		defer ticker.Stop()
+		digestTicks = ticker.C
+	}
+
 	// Create AWS config for SQS
 	awsConfig, err := providers.CreateAWSConfig(ctx, cfg.AWS.S3Endpoint, cfg.AWS.Region)
 	if err != nil {
@@ -62,6 +77,11 @@ func main() {
 		log.Fatalf("Failed to subscribe to queue: %v", err)
 	}
 
+	// Back-in-stock emails go out in throttled batches, away from the other
+	// notifications
+	backInStock := newBackInStockSender(emailNotifier, cfg.BackInStock.BatchInterval)
+	go backInStock.run()
+
 	// Handle graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -71,25 +91,52 @@ func main() {
 	for {
 		select {
 		case msg := <-messages:
-			if err := processMessage(msg, emailNotifier); err != nil {
+			// A full back-in-stock queue must not hold up the other
+			// notifications, so the message is left for redelivery instead
+			if msg.Metadata.Get("event_type") == notifications.ProductBackInStock {
+				if !backInStock.enqueue(msg) {
+					log.Printf("Back in stock queue is full, leaving message %s for redelivery", msg.UUID)
+					msg.Nack()
+				}
+				continue
+			}
+
+			if err := processMessage(msg, emailNotifier, lowStock); err != nil {
 				log.Printf("Error processing message: %v", err)
 				msg.Nack()
 			} else {
 				msg.Ack()
 			}
+		case <-digestTicks:
+			if err := lowStock.sendDigest(); err != nil {
+				log.Printf("Error sending low stock digest, keeping its alerts for the next one: %v", err)
+			}
 		case <-sigChan:
 			log.Println("Shutting down notification service...")
+			// Don't drop the alerts collected for the next digest
+			if lowStock.digest != nil {
+				if err := lowStock.sendDigest(); err != nil {
+					log.Printf("Error sending low stock digest: %v", err)
+				}
+			}
+			backInStock.stop()
 			subscriber.Close()
 			return
 		}
 	}
 }
 
-func processMessage(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+func processMessage(msg *message.Message, emailNotifier *notifications.EmailNotifier, lowStock *lowStockAlerts) error {
 	eventType := msg.Metadata.Get("event_type")
 	switch eventType {
 	case notifications.UserLoggedIn:
 		return handleUserLoggedIn(msg, emailNotifier)
+	case notifications.OrderDownloadsReady:
+		return handleOrderDownloadsReady(msg, emailNotifier)
+	case notifications.ReviewSubmitted:
+		return handleReviewSubmitted(msg, emailNotifier)
+	case notifications.ProductLowStock:
+		return lowStock.handle(msg)
 	default:
 		log.Printf("Unknown event type: %s", eventType)
 		return nil
@@ -111,3 +158,185 @@ func handleUserLoggedIn(msg *message.Message, emailNotifier *notifications.Email
 
 	return emailNotifier.SendLoginNotification(user.Email, userName)
 }
+
+func handleOrderDownloadsReady(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.DownloadsReadyPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.FirstName + " " + payload.LastName
+	if userName == " " {
+		userName = "Customer"
+	}
+
+	log.Printf("Sending download links for order %d to %s", payload.OrderID, payload.Email)
+
+	return emailNotifier.SendDownloadLinks(payload.Email, userName, payload.OrderID, payload.Links)
+}
+
+func handleReviewSubmitted(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.ReviewSubmittedPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.FirstName + " " + payload.LastName
+	if userName == " " {
+		userName = "Customer"
+	}
+
+	log.Printf("Sending review confirmation for review %d to %s", payload.ReviewID, payload.Email)
+
+	return emailNotifier.SendReviewReceived(payload.Email, userName, payload.ProductName)
+}
+
+// lowStockAlerts emails the staff list about low stock, as alerts arrive or,
+// in digest mode, every digest interval
+type lowStockAlerts struct {
+	notifier   *notifications.EmailNotifier
+	recipients []string
+
+	// digest is nil when alerts are sent as they arrive
+	digest *notifications.LowStockDigest
+}
+
+func (a *lowStockAlerts) handle(msg *message.Message) error {
+	var payload notifications.LowStockPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	if len(a.recipients) == 0 {
+		log.Printf("No recipients for the low stock alert of product %d", payload.ProductID)
+		return nil
+	}
+
+	if a.digest != nil {
+		a.digest.Add(payload)
+		return nil
+	}
+
+	log.Printf("Sending low stock alert for product %d", payload.ProductID)
+
+	return a.notifier.SendLowStockAlerts(a.recipients, []notifications.LowStockPayload{payload})
+}
+
+// sendDigest emails the alerts collected since the last digest. The messages
+// were already acknowledged, so when the email fails the alerts are kept for
+// the next digest.
+func (a *lowStockAlerts) sendDigest() error {
+	alerts := a.digest.Take()
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	log.Printf("Sending low stock digest of %d products", len(alerts))
+
+	if err := a.notifier.SendLowStockAlerts(a.recipients, alerts); err != nil {
+		a.digest.Restore(alerts)
+		return err
+	}
+
+	return nil
+}
+
+// backInStockSender emails the subscribers of products back in stock one batch
+// at a time, waiting between batches so a popular restock doesn't flood the
+// mail server
+type backInStockSender struct {
+	notifier *notifications.EmailNotifier
+	interval time.Duration
+	queue    chan *message.Message
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+func newBackInStockSender(notifier *notifications.EmailNotifier, interval time.Duration) *backInStockSender {
+	return &backInStockSender{
+		notifier: notifier,
+		interval: interval,
+		queue:    make(chan *message.Message, 16),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// enqueue queues msg for sending without blocking, and reports whether there
+// was room for it
+func (b *backInStockSender) enqueue(msg *message.Message) bool {
+	select {
+	case b.queue <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop waits for the batch being sent to finish. Batches still queued are
+// nacked to be redelivered rather than waiting out the interval between them.
+func (b *backInStockSender) stop() {
+	close(b.stopping)
+	<-b.done
+}
+
+func (b *backInStockSender) run() {
+	defer close(b.done)
+
+	for {
+		select {
+		case <-b.stopping:
+			b.nackQueued()
+			return
+		case msg := <-b.queue:
+			if err := b.send(msg); err != nil {
+				log.Printf("Error processing message: %v", err)
+				msg.Nack()
+			} else {
+				msg.Ack()
+			}
+		}
+
+		select {
+		case <-b.stopping:
+			b.nackQueued()
+			return
+		case <-time.After(b.interval):
+		}
+	}
+}
+
+func (b *backInStockSender) nackQueued() {
+	for {
+		select {
+		case msg := <-b.queue:
+			msg.Nack()
+		default:
+			return
+		}
+	}
+}
+
+func (b *backInStockSender) send(msg *message.Message) error {
+	var payload notifications.BackInStockPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	log.Printf("Sending back in stock emails for product %d to %d subscribers", payload.ProductID, len(payload.Subscribers))
+
+	// The subscriptions are already cleared, so a failed email is logged
+	// rather than retrying the whole batch
+	for _, subscriber := range payload.Subscribers {
+		userName := subscriber.FirstName + " " + subscriber.LastName
+		if userName == " " {
+			userName = "Customer"
+		}
+
+		if err := b.notifier.SendBackInStock(subscriber.Email, userName, payload.ProductName); err != nil {
+			log.Printf("Error sending back in stock email to %s: %v", subscriber.Email, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/services"
+)
+
+// runUploadGC deletes orphaned uploads once and prints what it found:
+//
+//	worker gc-uploads [-dry-run] [-grace 24h]
+func runUploadGC(uploadService services.UploadServiceInterface, gracePeriod time.Duration, args []string) error {
+	flags := flag.NewFlagSet("gc-uploads", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "list orphaned uploads without deleting them")
+	grace := flags.Duration("grace", gracePeriod, "only delete uploads older than this")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := uploadService.CollectOrphanedUploads(time.Now().Add(-*grace), *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, orphan := range report.Orphans {
+		fmt.Printf("%s\t%d bytes\t%s\n", orphan.Key, orphan.Size, orphan.ModifiedAt.Format(time.RFC3339))
+	}
+
+	if report.DryRun {
+		fmt.Printf("scanned %d files, %d orphaned, %d bytes would be reclaimed\n",
+			report.Scanned, len(report.Orphans), report.BytesReclaimed)
+		return nil
+	}
+
+	fmt.Printf("scanned %d files, deleted %d, %d failed, %d bytes reclaimed\n",
+		report.Scanned, report.Deleted, report.Failed, report.BytesReclaimed)
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/database"
+	"github.com/joefazee/learning-go-shop/internal/jobs"
+	"github.com/joefazee/learning-go-shop/internal/logger"
+	"github.com/joefazee/learning-go-shop/internal/providers"
+	"github.com/joefazee/learning-go-shop/internal/services"
+)
+
+func main() {
+	log := logger.New()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	db, err := database.New(&cfg.Database)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+
+	mainDB, err := db.DB()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to get database connection")
+	}
+	defer mainDB.Close()
+
+	uploadProvider, privateUploadProvider := providers.NewUploadProviders(cfg)
+
+	trashService := services.NewTrashService(db, cfg, uploadProvider)
+	cartService := services.NewCartService(db, cfg, uploadProvider)
+	uploadService := services.NewUploadService(db, cfg, uploadProvider, privateUploadProvider)
+	productService := services.NewProductService(db, cfg, uploadProvider)
+
+	// Jobs can also be run once from the command line
+	if len(os.Args) > 1 && os.Args[1] == "gc-uploads" {
+		if err := runUploadGC(uploadService, cfg.Upload.GCGracePeriod, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("upload garbage collection failed")
+		}
+		return
+	}
+
+	scheduler := jobs.NewScheduler(&log)
+	scheduler.Every(cfg.Trash.PurgeInterval, jobs.NewTrashPurgeJob(trashService, cfg.Trash.RetentionPeriod, &log))
+	scheduler.Every(cfg.Stock.ReservationSweepInterval, jobs.NewReservationSweepJob(cartService, &log))
+	scheduler.Every(cfg.Upload.GCInterval, jobs.NewUploadGCJob(uploadService, cfg.Upload.GCGracePeriod, &log))
+	scheduler.Every(cfg.Search.VocabularyRefreshInterval, jobs.NewSearchVocabularyJob(productService))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().Msg("worker started")
+	scheduler.Start(ctx)
+	log.Info().Msg("worker stopped")
+}
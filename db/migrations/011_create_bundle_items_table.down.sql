@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS order_item_components;
+DROP TABLE IF EXISTS bundle_items;
+
+ALTER TABLE products DROP COLUMN IF EXISTS bundle_percentage;
+ALTER TABLE products DROP COLUMN IF EXISTS bundle_pricing;
+ALTER TABLE products DROP COLUMN IF EXISTS is_bundle;
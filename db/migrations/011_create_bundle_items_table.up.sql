@@ -0,0 +1,29 @@
+ALTER TABLE products ADD COLUMN is_bundle BOOLEAN DEFAULT false;
+ALTER TABLE products ADD COLUMN bundle_pricing VARCHAR(20) DEFAULT 'fixed';
+ALTER TABLE products ADD COLUMN bundle_percentage DECIMAL(5,2) DEFAULT 100;
+
+CREATE TABLE bundle_items (
+    id SERIAL PRIMARY KEY,
+    bundle_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+CREATE INDEX idx_bundle_items_bundle_id ON bundle_items(bundle_id);
+CREATE INDEX idx_bundle_items_component_id ON bundle_items(component_id);
+
+-- Components shipped for each bundle line, recorded at order time for fulfillment
+CREATE TABLE order_item_components (
+    id SERIAL PRIMARY KEY,
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_item_components_order_item_id ON order_item_components(order_item_id);
+CREATE INDEX idx_order_item_components_product_id ON order_item_components(product_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS download_grants;
+DROP TABLE IF EXISTS product_files;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS requires_shipping;
+ALTER TABLE products DROP COLUMN IF EXISTS is_digital;
@@ -0,0 +1,31 @@
+ALTER TABLE products ADD COLUMN is_digital BOOLEAN DEFAULT false;
+ALTER TABLE orders ADD COLUMN requires_shipping BOOLEAN NOT NULL DEFAULT true;
+
+CREATE TABLE product_files (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    storage_key VARCHAR(500) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255),
+    size BIGINT DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_files_product_id ON product_files(product_id);
+CREATE INDEX idx_product_files_deleted_at ON product_files(deleted_at);
+
+CREATE TABLE download_grants (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_file_id INTEGER NOT NULL REFERENCES product_files(id) ON DELETE CASCADE,
+    download_count INTEGER NOT NULL DEFAULT 0,
+    max_downloads INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(order_id, product_file_id)
+);
+
+CREATE INDEX idx_download_grants_order_id ON download_grants(order_id);
+CREATE INDEX idx_download_grants_user_id ON download_grants(user_id);
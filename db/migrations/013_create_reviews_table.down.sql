@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_products_rating;
+
+ALTER TABLE products DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_average;
+
+DROP TABLE IF EXISTS review_votes;
+DROP TABLE IF EXISTS reviews;
+
+DROP TYPE IF EXISTS review_status;
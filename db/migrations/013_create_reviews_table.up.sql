@@ -0,0 +1,37 @@
+CREATE TYPE review_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(255),
+    body TEXT NOT NULL,
+    is_verified_purchase BOOLEAN NOT NULL DEFAULT false,
+    status review_status DEFAULT 'pending',
+    helpful_count INTEGER NOT NULL DEFAULT 0,
+    moderated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One review per user and product
+CREATE UNIQUE INDEX idx_reviews_product_user ON reviews(product_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_reviews_product_status ON reviews(product_id, status);
+CREATE INDEX idx_reviews_status ON reviews(status);
+CREATE INDEX idx_reviews_deleted_at ON reviews(deleted_at);
+
+CREATE TABLE review_votes (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(review_id, user_id)
+);
+
+-- Aggregates over approved reviews, kept up to date on moderation
+ALTER TABLE products ADD COLUMN rating_average DECIMAL(3,2) DEFAULT 0;
+ALTER TABLE products ADD COLUMN rating_count INTEGER DEFAULT 0;
+
+CREATE INDEX idx_products_rating ON products(rating_average DESC, rating_count DESC);
@@ -0,0 +1,5 @@
+UPDATE products
+SET deleted_at = NULL
+FROM categories
+WHERE products.category_id = categories.id
+  AND products.deleted_at = categories.deleted_at;
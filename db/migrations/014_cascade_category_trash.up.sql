@@ -0,0 +1,9 @@
+-- Products of categories deleted before cascading soft deletes were left
+-- visible. Trash them with the category's timestamp so a cascading restore
+-- brings them back together.
+UPDATE products
+SET deleted_at = categories.deleted_at
+FROM categories
+WHERE products.category_id = categories.id
+  AND categories.deleted_at IS NOT NULL
+  AND products.deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS revisions_immutable ON revisions;
+DROP FUNCTION IF EXISTS prevent_revision_changes();
+
+DROP TABLE IF EXISTS revisions;
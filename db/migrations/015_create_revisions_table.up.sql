@@ -0,0 +1,33 @@
+CREATE TABLE revisions (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    rollback_of_id INTEGER REFERENCES revisions(id),
+    changes JSONB NOT NULL DEFAULT '{}',
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(entity_type, entity_id, version)
+);
+
+CREATE INDEX idx_revisions_actor_id ON revisions(actor_id);
+
+-- Revisions are an audit log and must never be rewritten. Clearing actor_id
+-- when a user is deleted is the only update allowed.
+CREATE FUNCTION prevent_revision_changes() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.actor_id IS NULL
+        AND (to_jsonb(NEW) - 'actor_id') = (to_jsonb(OLD) - 'actor_id') THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'revisions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER revisions_immutable
+    BEFORE UPDATE OR DELETE ON revisions
+    FOR EACH ROW EXECUTE FUNCTION prevent_revision_changes();
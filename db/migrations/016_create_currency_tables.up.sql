@@ -0,0 +1,39 @@
+-- rate is the number of units of currency worth one unit of the store's base currency
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    currency VARCHAR(3) UNIQUE NOT NULL,
+    rate DECIMAL(18,8) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Per-currency price list entries override the converted base price
+CREATE TABLE product_prices (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_id, currency)
+);
+
+CREATE INDEX idx_product_prices_currency ON product_prices(currency);
+
+-- Orders placed before multi-currency support were all in the base currency,
+-- which migrate passes in as the app.base_currency setting (see the README)
+ALTER TABLE orders
+    ADD COLUMN currency VARCHAR(3),
+    ADD COLUMN exchange_rate DECIMAL(18,8) NOT NULL DEFAULT 1;
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM orders)
+        AND COALESCE(current_setting('app.base_currency', true), '') = '' THEN
+        RAISE EXCEPTION 'app.base_currency must be set to the store base currency to migrate existing orders';
+    END IF;
+END $$;
+
+UPDATE orders SET currency = upper(current_setting('app.base_currency', true));
+
+ALTER TABLE orders ALTER COLUMN currency SET NOT NULL;
@@ -0,0 +1,9 @@
+COMMENT ON COLUMN products.price IS NULL;
+COMMENT ON COLUMN product_prices.price IS NULL;
+COMMENT ON COLUMN orders.total_amount IS NULL;
+COMMENT ON COLUMN order_items.price IS NULL;
+
+ALTER TABLE order_items ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
+ALTER TABLE orders ALTER COLUMN total_amount TYPE DECIMAL(10,2) USING total_amount / 100.0;
+ALTER TABLE product_prices ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
+ALTER TABLE products ALTER COLUMN price TYPE DECIMAL(10,2) USING price / 100.0;
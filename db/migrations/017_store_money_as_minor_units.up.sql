@@ -0,0 +1,11 @@
+-- Money is stored as integer minor units (cents) so the application never
+-- does float arithmetic on it. Existing two-decimal values convert exactly.
+ALTER TABLE products ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE product_prices ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE orders ALTER COLUMN total_amount TYPE BIGINT USING ROUND(total_amount * 100);
+ALTER TABLE order_items ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+
+COMMENT ON COLUMN products.price IS 'minor units of the base currency';
+COMMENT ON COLUMN product_prices.price IS 'minor units of currency';
+COMMENT ON COLUMN orders.total_amount IS 'minor units of the order currency';
+COMMENT ON COLUMN order_items.price IS 'minor units of the order currency';
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS product_translations;
+DROP FUNCTION IF EXISTS product_translations_search_vector_update();
+
+CREATE OR REPLACE FUNCTION products_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+            setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(NEW.description, '')), 'B') ||
+            setweight(to_tsvector('english', coalesce(NEW.sku, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE products SET search_vector =
+                        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+                        setweight(to_tsvector('english', coalesce(description, '')), 'B') ||
+                        setweight(to_tsvector('english', coalesce(sku, '')), 'C');
+
+DROP FUNCTION IF EXISTS locale_search_config(TEXT);
@@ -0,0 +1,75 @@
+-- Maps a locale to the text search configuration for its language
+CREATE FUNCTION locale_search_config(locale TEXT) RETURNS regconfig AS $$
+    SELECT CASE split_part(lower(locale), '-', 1)
+        WHEN 'en' THEN 'english'
+        WHEN 'fr' THEN 'french'
+        WHEN 'de' THEN 'german'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'pt' THEN 'portuguese'
+        ELSE 'simple'
+    END::regconfig;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE product_translations (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    search_vector tsvector,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_id, locale)
+);
+
+CREATE TABLE category_translations (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(category_id, locale)
+);
+
+-- Product content is stored in the default locale (en); its search vector
+-- now takes the configuration from the locale instead of a literal
+CREATE OR REPLACE FUNCTION products_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+            setweight(to_tsvector(locale_search_config('en'), coalesce(NEW.name, '')), 'A') ||
+            setweight(to_tsvector(locale_search_config('en'), coalesce(NEW.description, '')), 'B') ||
+            setweight(to_tsvector('simple', coalesce(NEW.sku, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE products SET search_vector =
+                        setweight(to_tsvector(locale_search_config('en'), coalesce(name, '')), 'A') ||
+                        setweight(to_tsvector(locale_search_config('en'), coalesce(description, '')), 'B') ||
+                        setweight(to_tsvector('simple', coalesce(sku, '')), 'C');
+
+-- Translations get a search vector in their own language, with the SKU so
+-- searches by SKU keep working in every locale
+CREATE FUNCTION product_translations_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+            setweight(to_tsvector(locale_search_config(NEW.locale), coalesce(NEW.name, '')), 'A') ||
+            setweight(to_tsvector(locale_search_config(NEW.locale), coalesce(NEW.description, '')), 'B') ||
+            setweight(to_tsvector('simple', coalesce((SELECT sku FROM products WHERE id = NEW.product_id), '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_translations_search_vector_trigger
+    BEFORE INSERT OR UPDATE ON product_translations
+    FOR EACH ROW
+EXECUTE FUNCTION product_translations_search_vector_update();
+
+CREATE INDEX idx_product_translations_search_vector ON product_translations USING GIN(search_vector);
+
+COMMENT ON COLUMN product_translations.search_vector IS
+    'Full-text search vector in the locale''s language: A=name, B=description, C=sku';
@@ -0,0 +1,19 @@
+-- reserved is the number of units held by active reservations, so the
+-- available stock is stock - reserved
+ALTER TABLE products ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0);
+
+-- Soft holds on physical stock, one row per user and product. Bundles reserve
+-- their components.
+CREATE TABLE stock_reservations (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, product_id)
+);
+
+CREATE INDEX idx_stock_reservations_product_id ON stock_reservations(product_id);
+CREATE INDEX idx_stock_reservations_expires_at ON stock_reservations(expires_at);
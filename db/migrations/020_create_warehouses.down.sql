@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_item_allocations;
+DROP TABLE IF EXISTS warehouse_stocks;
+DROP TABLE IF EXISTS warehouses;
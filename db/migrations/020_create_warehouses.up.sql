@@ -0,0 +1,49 @@
+-- Warehouses are tried in priority order (lowest first) when allocating
+-- orders; the default warehouse receives stock set on the product itself
+CREATE TABLE warehouses (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_warehouses_default ON warehouses(is_default) WHERE is_default;
+
+-- products.stock is kept as the total over all warehouses
+CREATE TABLE warehouse_stocks (
+    id SERIAL PRIMARY KEY,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(warehouse_id, product_id)
+);
+
+CREATE INDEX idx_warehouse_stocks_product_id ON warehouse_stocks(product_id);
+
+-- The warehouse each order line, or bundle component, is shipped from. A line
+-- split across warehouses has one row per warehouse.
+CREATE TABLE order_item_allocations (
+    id SERIAL PRIMARY KEY,
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_item_allocations_order_item_id ON order_item_allocations(order_item_id);
+
+-- Existing stock moves into a default warehouse
+INSERT INTO warehouses (code, name, is_default) VALUES ('MAIN', 'Main warehouse', TRUE);
+
+INSERT INTO warehouse_stocks (warehouse_id, product_id, quantity)
+SELECT w.id, p.id, p.stock
+FROM products p CROSS JOIN warehouses w
+WHERE w.code = 'MAIN' AND p.stock > 0;
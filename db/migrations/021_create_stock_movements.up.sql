@@ -0,0 +1,33 @@
+-- Append-only ledger of stock changes per warehouse. quantity is signed: units
+-- received are positive, units shipped or written off negative.
+CREATE TABLE stock_movements (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity <> 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('sale', 'restock', 'adjustment', 'return', 'damage', 'transfer')),
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, created_at);
+CREATE INDEX idx_stock_movements_warehouse_id ON stock_movements(warehouse_id);
+
+-- Movements are never edited. Rows only go away with their product when it
+-- is purged from the trash.
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock movements are append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_no_update
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- Open the ledger with the current stock levels
+INSERT INTO stock_movements (product_id, warehouse_id, quantity, reason, reference)
+SELECT product_id, warehouse_id, quantity, 'adjustment', 'opening balance'
+FROM warehouse_stocks
+WHERE quantity > 0;
@@ -0,0 +1,3 @@
+-- low_stock_threshold overrides the store-wide threshold for low-stock alerts,
+-- NULL uses the store-wide one and 0 turns alerts off for the product
+ALTER TABLE products ADD COLUMN low_stock_threshold INTEGER CHECK (low_stock_threshold >= 0);
@@ -0,0 +1,11 @@
+-- Customers waiting to hear when an out of stock product is back. Rows are
+-- removed once the subscriber has been notified.
+CREATE TABLE stock_subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, product_id)
+);
+
+CREATE INDEX idx_stock_subscriptions_product_id ON stock_subscriptions(product_id);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_order_items_backordered;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS expected_ship_date,
+    DROP COLUMN IF EXISTS backordered;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS expected_ship_date,
+    DROP COLUMN IF EXISTS backordered,
+    DROP COLUMN IF EXISTS backorder_limit,
+    DROP COLUMN IF EXISTS stock_policy;
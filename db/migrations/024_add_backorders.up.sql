@@ -0,0 +1,15 @@
+-- Products can be sold beyond their stock. backordered is the units sold but
+-- not yet taken from stock, capped by backorder_limit unless that is 0.
+ALTER TABLE products
+    ADD COLUMN stock_policy VARCHAR(20) NOT NULL DEFAULT 'deny' CHECK (stock_policy IN ('deny', 'backorder', 'preorder')),
+    ADD COLUMN backorder_limit INTEGER NOT NULL DEFAULT 0 CHECK (backorder_limit >= 0),
+    ADD COLUMN backordered INTEGER NOT NULL DEFAULT 0 CHECK (backordered >= 0),
+    ADD COLUMN expected_ship_date TIMESTAMP WITH TIME ZONE;
+
+-- Order lines remember the units still waiting for stock and when they were
+-- expected to ship at the time of the order
+ALTER TABLE order_items
+    ADD COLUMN backordered INTEGER NOT NULL DEFAULT 0 CHECK (backordered >= 0),
+    ADD COLUMN expected_ship_date TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_order_items_backordered ON order_items(product_id, id) WHERE backordered > 0;
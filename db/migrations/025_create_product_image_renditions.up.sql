@@ -0,0 +1,14 @@
+-- Resized versions of product images, in the image's own format and as WebP.
+-- product_images.url stays the full size original, stripped of metadata.
+CREATE TABLE product_image_renditions (
+    id SERIAL PRIMARY KEY,
+    product_image_id INTEGER NOT NULL REFERENCES product_images(id) ON DELETE CASCADE,
+    name VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_image_id, name, format)
+);
@@ -0,0 +1,14 @@
+-- Uploads a client has been given a presigned URL for but has not confirmed.
+-- A row is removed once its upload is confirmed and processed.
+CREATE TABLE pending_uploads (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    key VARCHAR(500) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pending_uploads_expires_at ON pending_uploads(expires_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_product_images_position;
+DROP INDEX IF EXISTS idx_product_images_primary;
+CREATE INDEX idx_product_images_is_primary ON product_images(is_primary);
+
+ALTER TABLE product_images ALTER COLUMN is_primary DROP NOT NULL;
+ALTER TABLE product_images DROP COLUMN IF EXISTS position;
@@ -0,0 +1,24 @@
+-- Images are shown in position order, and each product with images has
+-- exactly one primary image.
+ALTER TABLE product_images ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE product_images
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY is_primary DESC, id) - 1 AS position
+    FROM product_images
+    WHERE deleted_at IS NULL
+) AS ordered
+WHERE product_images.id = ordered.id;
+
+UPDATE product_images
+SET is_primary = (position = 0)
+WHERE deleted_at IS NULL;
+
+UPDATE product_images SET is_primary = false WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE product_images ALTER COLUMN is_primary SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_product_images_is_primary;
+CREATE UNIQUE INDEX idx_product_images_primary ON product_images(product_id) WHERE is_primary AND deleted_at IS NULL;
+CREATE INDEX idx_product_images_position ON product_images(product_id, position);
@@ -0,0 +1,13 @@
+-- Images are stored under keys derived from the SHA-256 of the uploaded file,
+-- so identical uploads share one set of stored files. image_objects counts the
+-- product images using each set, which is deleted when the count reaches zero.
+-- Images uploaded before this have no hash and own their files.
+ALTER TABLE product_images ADD COLUMN content_hash CHAR(64);
+
+CREATE INDEX idx_product_images_content_hash ON product_images(content_hash);
+
+CREATE TABLE image_objects (
+    content_hash CHAR(64) PRIMARY KEY,
+    ref_count INTEGER NOT NULL DEFAULT 0 CHECK (ref_count >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,26 @@
+-- Search suggestions match partial and misspelled words with trigrams
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Only names of products and categories that can be shown are suggested;
+-- the suggestion queries repeat these predicates so the indexes apply
+CREATE INDEX idx_products_name_trgm ON products USING GIN (lower(name) gin_trgm_ops)
+    WHERE deleted_at IS NULL AND is_active = true;
+CREATE INDEX idx_product_translations_name_trgm ON product_translations USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX idx_categories_name_trgm ON categories USING GIN (lower(name) gin_trgm_ops)
+    WHERE deleted_at IS NULL AND is_active = true;
+CREATE INDEX idx_category_translations_name_trgm ON category_translations USING GIN (lower(name) gin_trgm_ops);
+
+-- Searches that found products, normalized and counted per locale, so
+-- popular ones can be suggested
+CREATE TABLE search_queries (
+    id SERIAL PRIMARY KEY,
+    locale VARCHAR(10) NOT NULL,
+    query VARCHAR(100) NOT NULL,
+    search_count INTEGER NOT NULL DEFAULT 1,
+    result_count INTEGER NOT NULL DEFAULT 0,
+    last_searched_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(locale, query)
+);
+
+CREATE INDEX idx_search_queries_prefix ON search_queries(locale, query text_pattern_ops);
+CREATE INDEX idx_search_queries_query_trgm ON search_queries USING GIN (query gin_trgm_ops);
@@ -0,0 +1,3 @@
+DROP MATERIALIZED VIEW IF EXISTS search_vocabulary;
+
+DROP INDEX IF EXISTS idx_products_sku_trgm;
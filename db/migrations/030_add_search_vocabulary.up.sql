@@ -0,0 +1,29 @@
+-- Searches that find few products fall back to trigram similarity on product
+-- names and SKUs; names are indexed by 029_add_search_suggestions
+CREATE INDEX idx_products_sku_trgm ON products USING GIN (lower(sku) gin_trgm_ops)
+    WHERE deleted_at IS NULL AND is_active = true;
+
+-- The words of catalog names, with the number of names using each. Misspelt
+-- search words are corrected to the most similar one for "did you mean".
+-- The worker refreshes it as the catalog changes.
+CREATE MATERIALIZED VIEW search_vocabulary AS
+SELECT word, ndoc
+FROM ts_stat($$
+    SELECT to_tsvector('simple', name) FROM products WHERE deleted_at IS NULL AND is_active = true
+    UNION ALL
+    SELECT to_tsvector('simple', pt.name)
+    FROM product_translations pt
+    JOIN products p ON p.id = pt.product_id
+    WHERE p.deleted_at IS NULL AND p.is_active = true
+    UNION ALL
+    SELECT to_tsvector('simple', name) FROM categories WHERE deleted_at IS NULL AND is_active = true
+    UNION ALL
+    SELECT to_tsvector('simple', ct.name)
+    FROM category_translations ct
+    JOIN categories c ON c.id = ct.category_id
+    WHERE c.deleted_at IS NULL AND c.is_active = true
+$$);
+
+-- The unique index lets the view be refreshed concurrently with searches
+CREATE UNIQUE INDEX idx_search_vocabulary_word ON search_vocabulary(word);
+CREATE INDEX idx_search_vocabulary_word_trgm ON search_vocabulary USING GIN (word gin_trgm_ops);
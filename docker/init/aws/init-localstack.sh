@@ -2,6 +2,7 @@
 
 # Create bucket
 awslocal s3 mb s3://ecommerce-uploads
+awslocal s3 mb s3://ecommerce-private
 
 # Create SQS queue
 awslocal sqs create-queue --queue-name ecommerce-events
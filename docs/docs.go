@@ -235,6 +235,32 @@ const docTemplate = `{
                     "Cart"
                 ],
                 "summary": "Get user's cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Cart retrieved successfully",
@@ -296,6 +322,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.AddToCartRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -366,6 +416,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UpdateCartItemRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -443,6 +517,113 @@ const docTemplate = `{
                 }
             }
         },
+        "/cart/reservation": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hold the stock of the cart's items while checkout is in progress. The hold expires after the configured TTL; reserving again extends it and picks up cart changes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Reserve cart stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart reserved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Cart is empty",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give back the stock held for the cart, e.g. when checkout is abandoned",
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Release cart reservation",
+                "responses": {
+                    "200": {
+                        "description": "Reservation released successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/categories": {
             "get": {
                 "description": "Retrieve all active categories",
@@ -453,6 +634,20 @@ const docTemplate = `{
                     "Categories"
                 ],
                 "summary": "Get all categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Categories retrieved successfully",
@@ -632,7 +827,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a category (Admin only)",
+                "description": "Move a category and its products to the trash (Admin only)",
                 "tags": [
                     "Categories"
                 ],
@@ -670,26 +865,39 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
                     }
                 }
             }
         },
-        "/orders": {
+        "/categories/{id}/revisions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of user's orders",
+                "description": "Retrieve the revision history of a category, newest first (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Revisions"
                 ],
-                "summary": "Get user's orders",
+                "summary": "Get category history",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -707,7 +915,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Orders retrieved successfully",
+                        "description": "Revisions retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -719,7 +927,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderResponse"
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.RevisionResponse"
                                             }
                                         }
                                     }
@@ -727,37 +935,54 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/categories/{id}/translations": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create an order from the current user's cart",
+                "description": "List the translations of a category's name and description (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Translations"
+                ],
+                "summary": "Get category translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Create an order",
                 "responses": {
-                    "201": {
-                        "description": "Order created successfully",
+                    "200": {
+                        "description": "Translations retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -767,7 +992,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.TranslationResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -775,7 +1003,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Cart is empty or insufficient stock",
+                        "description": "Invalid category ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
@@ -785,37 +1013,62 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
                     }
                 }
             }
         },
-        "/orders/{id}": {
-            "get": {
+        "/categories/{id}/translations/{locale}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information about a specific order",
+                "description": "Create or replace the translation of a category into a supported locale. An empty description falls back to the default locale's (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Translations"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Set a category translation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SetTranslationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order retrieved successfully",
+                        "description": "Translation saved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -825,7 +1078,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderResponse"
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.TranslationResponse"
                                         }
                                     }
                                 }
@@ -833,7 +1086,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID",
+                        "description": "Invalid request data or locale",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
@@ -844,120 +1097,59 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     }
                 }
-            }
-        },
-        "/products": {
-            "get": {
-                "description": "Retrieve paginated list of active products",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a category translation so the locale falls back to the default content (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Translations"
                 ],
-                "summary": "Get all products",
+                "summary": "Delete a category translation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products retrieved successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Translation deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new product (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Products"
-                ],
-                "summary": "Create a new product",
-                "parameters": [
-                    {
-                        "description": "Product data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CreateProductRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Product created successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid category ID or locale",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
@@ -973,32 +1165,29 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
+                    },
+                    "404": {
+                        "description": "Translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
                     }
                 }
             }
         },
-        "/products/{id}": {
+        "/currencies": {
             "get": {
-                "description": "Retrieve detailed information about a specific product",
+                "description": "List the store's base currency and every currency prices can be shown in",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Get a product by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Currencies"
                 ],
+                "summary": "List currencies",
                 "responses": {
                     "200": {
-                        "description": "Product retrieved successfully",
+                        "description": "Currencies retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1008,34 +1197,33 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CurrencyResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid product ID",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Product not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/currencies/{code}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing product (Admin only)",
+                "description": "Add a currency or update its rate. The rate is the number of units of the currency worth one unit of the base currency (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1043,30 +1231,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Currencies"
                 ],
-                "summary": "Update a product",
+                "summary": "Set an exchange rate",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product update data",
+                        "description": "Exchange rate",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UpdateProductRequest"
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SetExchangeRateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product updated successfully",
+                        "description": "Exchange rate saved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1076,7 +1264,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CurrencyResponse"
                                         }
                                     }
                                 }
@@ -1084,7 +1272,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid request data or currency",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
@@ -1109,29 +1297,32 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product (Admin only)",
+                "description": "Stop offering a currency and drop its price list. Existing orders keep their currency and rate (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Products"
+                    "Currencies"
                 ],
-                "summary": "Delete a product",
+                "summary": "Remove a currency",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted successfully",
+                        "description": "Currency removed successfully",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid currency",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
@@ -1147,47 +1338,89 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
+                    },
+                    "404": {
+                        "description": "Currency not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
                     }
                 }
             }
         },
-        "/products/{id}/images": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Upload an image for a product (Admin only)",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/downloads/{id}": {
+            "get": {
+                "description": "Stream a purchased file using a signed link from the order downloads endpoint",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Upload product image",
+                "summary": "Download a digital product file",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Download ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Link expiry as a unix timestamp",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Image uploaded successfully",
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired link",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Download limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare every warehouse stock level and product total with the sum of its ledger movements and list the mismatches (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Reconcile stock",
+                "responses": {
+                    "200": {
+                        "description": "Stock reconciled successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1197,22 +1430,13 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.StockReconciliationResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request or file",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1228,34 +1452,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/profile": {
+        "/orders": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get current authenticated user's profile information",
+                "description": "Retrieve paginated list of user's orders",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Orders"
+                ],
+                "summary": "Get user's orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "Profile retrieved successfully",
+                        "description": "Orders retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UserResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -1268,21 +1523,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current authenticated user's profile information",
+                "description": "Create an order from the current user's cart. The optional shipping location is used to ship from the closest warehouses",
                 "consumes": [
                     "application/json"
                 ],
@@ -1290,23 +1545,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Orders"
                 ],
-                "summary": "Update user profile",
+                "summary": "Create an order",
                 "parameters": [
                     {
-                        "description": "Profile update data",
+                        "description": "Shipping location",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UpdateProfileRequest"
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CreateOrderRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Profile updated successfully",
+                    "201": {
+                        "description": "Order created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1316,7 +1594,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UserResponse"
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderResponse"
                                         }
                                     }
                                 }
@@ -1324,7 +1602,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Cart is empty or insufficient stock",
                         "schema": {
                             "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
                         }
@@ -1337,94 +1615,5048 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_joefazee_learning-go-shop_internal_dto.AddToCartRequest": {
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information about a specific order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/downloads": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get expiring signed download links for the digital products in an order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order downloads",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Downloads retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.DownloadLinkResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Retrieve paginated list of active products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "rating"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieve detailed information about a specific product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing product (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a product (Admin only)",
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/bundle": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn a product into a bundle of existing products, or replace its components (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Configure a product bundle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bundle components and pricing",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SetBundleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundle updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove all components and turn a bundle back into a regular product (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Remove a product bundle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundle removed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/files": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the files delivered to buyers of a digital product (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List digital product files",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Files retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductFileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a privately stored file delivered to buyers of a digital product (Admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload a digital product file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "File uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductFileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, file too large or product is not digital",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_services.UploadValidationError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image for a product (Admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative text describing the image",
+                        "name": "alt_text",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or file",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_services.UploadValidationError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an image uploaded with a presigned URL to the product (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Confirm a direct image upload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upload to confirm",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ConfirmImageUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or file",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_services.UploadValidationError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload has not been received",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Storage does not support direct uploads",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch an image from a URL and add it to the product (Admin only). The fetch is bounded in time and size.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Import a product image from a URL",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ImportProductImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image imported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or the image could not be fetched",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_services.UploadValidationError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the order a product's images are shown in. Every image of the product must be listed once. (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Reorder product images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image IDs in order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ReorderProductImagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Images reordered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or image order",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/presign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a URL to upload a product image straight to storage, then confirm it (Admin only). The upload must have exactly the declared content type and size.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Presign a direct image upload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image to upload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.PresignImageUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upload URL created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.PresignedUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or file",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_services.UploadValidationError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Storage does not support direct uploads",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/{imageId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit the alternative text of a product image (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UpdateProductImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a product image and its stored files (Admin only). If it was the primary image, the next image becomes primary.",
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/{imageId}/primary": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make an image the product's primary image, in place of the current one (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Set the primary product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Primary image updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the per-currency prices that override the converted base price of a product (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Currencies"
+                ],
+                "summary": "Get a product's price list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prices retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductPriceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices/{currency}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the price of a product in a supported currency, replacing the converted base price (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Currencies"
+                ],
+                "summary": "Set a product price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price in the currency",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SetProductPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductPriceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or currency",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a price list entry so the product is converted from its base price again (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Currencies"
+                ],
+                "summary": "Remove a product price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or currency",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Price not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/reviews": {
+            "get": {
+                "description": "Retrieve paginated approved reviews for a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get product reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "helpful",
+                            "rating_desc",
+                            "rating_asc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ReviewResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit a 1-5 star review for a product. Reviews are published after moderation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Review a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Review submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Product already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the revision history of a product, newest first (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Get product history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revisions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.RevisionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List a product's on-hand stock at every warehouse (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Get product stock by warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseStockResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add units to a product's stock at a warehouse, or remove them with a negative quantity. The change is recorded in the stock ledger with its reason: restock and return add units, damage removes them and adjustment does either (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Adjust product stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjustment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.AdjustStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock adjusted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseStockResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, a reason that disagrees with the quantity or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a product's stock ledger, newest first (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Get stock movements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock movements retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.StockMovementResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move units of a product from one warehouse to another. The ledger records the units leaving and arriving (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Transfer product stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.TransferStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transferred successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseStockResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the translations of a product's name and description (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Get product translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.TranslationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/translations/{locale}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace the translation of a product into a supported locale. An empty description falls back to the default locale's (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Set a product translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SetTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.TranslationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or locale",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a product translation so the locale falls back to the default content (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Delete a product translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or locale",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated reviews by moderation status, oldest first (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get the review moderation queue",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Review status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ReviewResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/helpful": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Vote for an approved review. Voting more than once has no effect.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Mark a review as helpful",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vote recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/moderation": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve or reject a review and update the product rating (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Moderate a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation decision",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ModerateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review moderated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/revisions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single revision with its diff and snapshot (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Get a revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revision retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.RevisionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid revision ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/revisions/{id}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a product or category to the state recorded in a revision. The rollback is recorded as a new revision (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Roll back to a revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rolled back successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.RevisionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Revision cannot be applied",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Search products using full-text search with ranking, in the requested locale. When few products match, products with similar names or SKUs are also returned, flagged as fuzzy, and a corrected query is sent in the X-Did-You-Mean header if the search looks misspelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Search products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price filter, in the requested currency",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price filter, in the requested currency",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, overrides the Accept-Currency header",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code, defaults to the store's base currency",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductSearchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Did-You-Mean": {
+                                "type": "string",
+                                "description": "Corrected query, percent-encoded, when the search looks misspelled"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search query",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/suggest": {
+            "get": {
+                "description": "Typeahead suggestions for a partial search query: products and categories by name, in the requested locale, and popular searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Suggest searches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partial search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Suggestions of each kind",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale, overrides the Accept-Language header",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages, content falls back to the default locale",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search suggestions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SearchSuggestionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search query",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the out of stock products the current user is waiting on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Subscriptions"
+                ],
+                "summary": "Get back-in-stock subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Subscriptions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.StockSubscriptionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe to an email when an out of stock product is back in stock. Subscribing again has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Subscriptions"
+                ],
+                "summary": "Get notified when a product is back in stock",
+                "parameters": [
+                    {
+                        "description": "Product to wait for",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CreateStockSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscribed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.StockSubscriptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Product is in stock",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-subscriptions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop waiting for a product to be back in stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Subscriptions"
+                ],
+                "summary": "Cancel a back-in-stock subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated soft-deleted products or categories, most recently deleted first (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Get trash",
+                "parameters": [
+                    {
+                        "enum": [
+                            "product",
+                            "category"
+                        ],
+                        "type": "string",
+                        "default": "product",
+                        "description": "Entity type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trash retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.TrashItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/categories/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently delete a trashed category and its trashed products. Fails while it has live products or orders reference its products (Admin only)",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category purged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Category cannot be purged",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Category products are referenced by orders or live bundles",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/categories/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a soft-deleted category, optionally with the products deleted along with it (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also restore the category's products",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category restored successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/products/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently delete a trashed product. Fails while orders reference it (Admin only)",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product purged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Product is referenced by orders or is a component of a live bundle",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/products/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a soft-deleted product. Its category must not be in the trash (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product restored successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Product cannot be restored",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get current authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current authenticated user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Profile update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the warehouses in the order they are tried when allocating orders (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Get warehouses",
+                "responses": {
+                    "200": {
+                        "description": "Warehouses retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a warehouse. Making it the default moves the default flag from the current default (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Create a warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Warehouse created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Warehouse code already in use",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a warehouse's details, location and priority (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Update a warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Warehouse updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.WarehouseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Warehouse code already in use",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an empty warehouse that has not shipped any orders (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Delete a warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Warehouse deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Warehouse cannot be deleted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Warehouse still holds stock",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_joefazee_learning-go-shop_internal_dto.AddToCartRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.AdjustStockRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason",
+                "warehouse_id"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "restock",
+                        "adjustment",
+                        "return",
+                        "damage"
+                    ]
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UserResponse"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.BundleItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.BundleItemResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CartItemResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CartResponse": {
+            "type": "object",
+            "properties": {
+                "cart_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CartItemResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reserved_until": {
+                    "description": "ReservedUntil is when the stock held for checkout is released, if any",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ConfirmImageUploadRequest": {
+            "type": "object",
+            "required": [
+                "upload_id"
+            ],
+            "properties": {
+                "alt_text": {
+                    "type": "string"
+                },
+                "upload_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CreateOrderRequest": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "name",
+                "price",
+                "sku"
+            ],
+            "properties": {
+                "backorder_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expected_ship_date": {
+                    "type": "string"
+                },
+                "is_digital": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "description": "LowStockThreshold overrides the store-wide low-stock threshold, 0 turns\nalerts off for the product",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stock_policy": {
+                    "description": "StockPolicy allows selling beyond stock: deny (the default), backorder\nor preorder. BackorderLimit caps the units owed to customers, 0 for no\nlimit. Pre-orders need an ExpectedShipDate.",
+                    "type": "string",
+                    "enum": [
+                        "deny",
+                        "backorder",
+                        "preorder"
+                    ]
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "rating"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CreateStockSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.CurrencyResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "is_base": {
+                    "type": "boolean"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.DownloadLinkResponse": {
+            "type": "object",
+            "properties": {
+                "downloads_remaining": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ImportProductImageRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "alt_text": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ModerateReviewRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "rejected"
+                    ]
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.OrderItemAllocationResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_code": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.OrderItemComponentResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderItemAllocationResponse"
+                    }
+                },
+                "backordered": {
+                    "description": "Backordered is the units still waiting for stock, expected to ship on\nExpectedShipDate when the product gave one",
+                    "type": "integer"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderItemComponentResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expected_ship_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderItemResponse"
+                    }
+                },
+                "requires_shipping": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.PresignImageUploadRequest": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "filename",
+                "size"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.PresignedUploadResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "upload_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ProductFileResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ProductImageRenditionResponse": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "alt_text": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "renditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageRenditionResponse"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "type": "string"
+                },
+                "available_stock": {
+                    "description": "OnHandStock is what is physically in stock, AvailableStock is that less\nthe units held by checkouts. Stock equals AvailableStock.",
+                    "type": "integer"
+                },
+                "backorder_limit": {
+                    "type": "integer"
+                },
+                "bundle_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.BundleItemResponse"
+                    }
+                },
+                "bundle_percentage": {
+                    "type": "number"
+                },
+                "bundle_pricing": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CategoryResponse"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expected_ship_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_bundle": {
+                    "type": "boolean"
+                },
+                "is_digital": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_hand_stock": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rating_average": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "stock_policy": {
+                    "description": "Availability is in_stock, backorder, preorder or out_of_stock. Backorders\nand pre-orders are expected to ship on ExpectedShipDate when it is given.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ProductSearchResult": {
             "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
-            ],
             "properties": {
-                "product_id": {
+                "availability": {
+                    "type": "string"
+                },
+                "available_stock": {
+                    "description": "OnHandStock is what is physically in stock, AvailableStock is that less\nthe units held by checkouts. Stock equals AvailableStock.",
                     "type": "integer"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "minimum": 1
+                "backorder_limit": {
+                    "type": "integer"
+                },
+                "bundle_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.BundleItemResponse"
+                    }
+                },
+                "bundle_percentage": {
+                    "type": "number"
+                },
+                "bundle_pricing": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CategoryResponse"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expected_ship_date": {
+                    "type": "string"
+                },
+                "fuzzy": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_bundle": {
+                    "type": "boolean"
+                },
+                "is_digital": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_hand_stock": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "rating_average": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "stock_policy": {
+                    "description": "Availability is in_stock, backorder, preorder or out_of_stock. Backorders\nand pre-orders are expected to ship on ExpectedShipDate when it is given.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.AuthResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.RefreshTokenRequest": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "access_token": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "first_name": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.UserResponse"
+                "last_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.CartItemResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.ReorderProductImagesRequest": {
+            "type": "object",
+            "required": [
+                "image_ids"
+            ],
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.ReviewResponse": {
             "type": "object",
             "properties": {
+                "author_name": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "helpful_count": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "product": {
-                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                "is_verified_purchase": {
+                    "type": "boolean"
                 },
-                "quantity": {
+                "product_id": {
                     "type": "integer"
                 },
-                "subtotal": {
-                    "type": "number"
+                "rating": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.CartResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.RevisionResponse": {
             "type": "object",
             "properties": {
-                "cart_items": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_email": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CartItemResponse"
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.FieldChange"
                     }
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "total": {
-                    "type": "number"
+                "rollback_of_id": {
+                    "type": "integer"
                 },
-                "user_id": {
+                "snapshot": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.CategoryResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.SearchSuggestion": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.CreateCategoryRequest": {
+        "github_com_joefazee_learning-go-shop_internal_dto.SearchSuggestionsResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SearchSuggestion"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.SearchSuggestion"
+                    }
+                },
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.SetBundleRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "pricing"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.BundleItemRequest"
+                    }
+                },
+                "percentage": {
+                    "type": "number",
+                    "maximum": 100
+                },
+                "pricing": {
+                    "type": "string",
+                    "enum": [
+                        "fixed",
+                        "percentage"
+                    ]
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.SetExchangeRateRequest": {
+            "type": "object",
+            "required": [
+                "rate"
+            ],
+            "properties": {
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.SetProductPriceRequest": {
+            "type": "object",
+            "required": [
+                "price"
+            ],
+            "properties": {
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.SetTranslationRequest": {
             "type": "object",
             "required": [
                 "name"
@@ -1438,183 +6670,170 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.CreateProductRequest": {
+        "github_com_joefazee_learning-go-shop_internal_dto.StockDiscrepancy": {
             "type": "object",
-            "required": [
-                "category_id",
-                "name",
-                "price",
-                "sku"
-            ],
             "properties": {
-                "category_id": {
+                "difference": {
                     "type": "integer"
                 },
-                "description": {
+                "ledger_quantity": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
                     "type": "string"
                 },
-                "name": {
+                "sku": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "stock_quantity": {
+                    "type": "integer"
                 },
-                "sku": {
+                "warehouse_code": {
                     "type": "string"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.LoginRequest": {
+        "github_com_joefazee_learning-go-shop_internal_dto.StockMovementResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "actor_email": {
                     "type": "string"
                 },
-                "password": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_joefazee_learning-go-shop_internal_dto.OrderItemResponse": {
-            "type": "object",
-            "properties": {
+                },
                 "id": {
                     "type": "integer"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "product": {
-                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductResponse"
+                "product_id": {
+                    "type": "integer"
                 },
                 "quantity": {
                     "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "warehouse_code": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.OrderResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.StockReconciliationResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+                "checked": {
                     "type": "integer"
                 },
-                "order_items": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "discrepancies": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.OrderItemResponse"
+                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.StockDiscrepancy"
                     }
-                },
-                "status": {
-                    "type": "string"
-                },
-                "total_amount": {
-                    "type": "number"
-                },
-                "user_id": {
-                    "type": "integer"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.StockSubscriptionResponse": {
             "type": "object",
             "properties": {
-                "alt_text": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_primary": {
-                    "type": "boolean"
+                "product_id": {
+                    "type": "integer"
                 },
-                "url": {
+                "product_name": {
+                    "type": "string"
+                },
+                "sku": {
                     "type": "string"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.ProductResponse": {
+        "github_com_joefazee_learning-go-shop_internal_dto.TransferStockRequest": {
             "type": "object",
+            "required": [
+                "from_warehouse_id",
+                "quantity",
+                "to_warehouse_id"
+            ],
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.CategoryResponse"
-                },
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
+                "from_warehouse_id": {
                     "type": "integer"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_joefazee_learning-go-shop_internal_dto.ProductImageResponse"
-                    }
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "sku": {
-                    "type": "string"
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "stock": {
+                "to_warehouse_id": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.RefreshTokenRequest": {
+        "github_com_joefazee_learning-go-shop_internal_dto.TranslationResponse": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
+                "description": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_joefazee_learning-go-shop_internal_dto.RegisterRequest": {
+        "github_com_joefazee_learning-go-shop_internal_dto.TrashItemResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "last_name",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "deleted_at": {
                     "type": "string"
                 },
-                "first_name": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "last_name": {
+                "purge_at": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "sku": {
+                    "type": "string"
                 },
-                "phone": {
+                "type": {
                     "type": "string"
                 }
             }
@@ -1648,6 +6867,15 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_joefazee_learning-go-shop_internal_dto.UpdateProductImageRequest": {
+            "type": "object",
+            "properties": {
+                "alt_text": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
         "github_com_joefazee_learning-go-shop_internal_dto.UpdateProductRequest": {
             "type": "object",
             "required": [
@@ -1656,24 +6884,44 @@ const docTemplate = `{
                 "price"
             ],
             "properties": {
+                "backorder_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "category_id": {
                     "type": "integer"
                 },
                 "description": {
                     "type": "string"
                 },
+                "expected_ship_date": {
+                    "type": "string"
+                },
                 "is_active": {
                     "type": "boolean"
                 },
+                "is_digital": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "description": "LowStockThreshold overrides the store-wide low-stock threshold, 0 turns\nalerts off for the product and null uses the store-wide threshold again",
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "name": {
                     "type": "string"
                 },
                 "price": {
                     "type": "number"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                "stock_policy": {
+                    "description": "StockPolicy allows selling beyond stock: deny (the default), backorder\nor preorder. BackorderLimit caps the units owed to customers, 0 for no\nlimit. Pre-orders need an ExpectedShipDate.",
+                    "type": "string",
+                    "enum": [
+                        "deny",
+                        "backorder",
+                        "preorder"
+                    ]
                 }
             }
         },
@@ -1698,6 +6946,9 @@ const docTemplate = `{
         "github_com_joefazee_learning-go-shop_internal_dto.UserResponse": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1718,6 +6969,107 @@ const docTemplate = `{
                 },
                 "role": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.WarehouseRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.WarehouseResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_dto.WarehouseStockResponse": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_code": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_joefazee_learning-go-shop_internal_services.UploadValidationError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
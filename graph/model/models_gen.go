@@ -36,3 +36,30 @@ type ProductEdge struct {
 
 type Query struct {
 }
+
+type ReviewConnection struct {
+	Edges    []*ReviewEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type ReviewEdge struct {
+	Node *dto.ReviewResponse `json:"node"`
+}
+
+type RevisionConnection struct {
+	Edges    []*RevisionEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type RevisionEdge struct {
+	Node *dto.RevisionResponse `json:"node"`
+}
+
+type StockMovementConnection struct {
+	Edges    []*StockMovementEdge `json:"edges"`
+	PageInfo *PageInfo            `json:"pageInfo"`
+}
+
+type StockMovementEdge struct {
+	Node *dto.StockMovementResponse `json:"node"`
+}
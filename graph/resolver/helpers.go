@@ -2,8 +2,12 @@ package resolver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
+	"github.com/joefazee/learning-go-shop/graph/model"
+	"github.com/joefazee/learning-go-shop/internal/dto"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 )
 
@@ -42,6 +46,15 @@ func GetUserRoleFromContext(ctx context.Context) (string, error) {
 	return "", ErrUnauthorized
 }
 
+// GetLocalizationFromContext returns the currency and locale resolved by the
+// currency and locale middlewares. Empty values select the store defaults.
+func GetLocalizationFromContext(ctx context.Context) dto.Localization {
+	currency, _ := ctx.Value(utils.CurrencyKey).(string)
+	locale, _ := ctx.Value(utils.LocaleKey).(string)
+
+	return dto.Localization{Currency: currency, Locale: locale}
+}
+
 func IsAdminFromContext(ctx context.Context) bool {
 	role, err := GetUserRoleFromContext(ctx)
 	if err != nil {
@@ -72,3 +85,93 @@ func getPagingNumbers(page, limit *int) (pageNumber, pageLimit int) {
 
 	return p, l
 }
+
+func newReviewConnection(reviews []dto.ReviewResponse, meta *utils.PaginationMeta) *model.ReviewConnection {
+	edges := make([]*model.ReviewEdge, len(reviews))
+	for i := range reviews {
+		edges[i] = &model.ReviewEdge{
+			Node: &reviews[i],
+		}
+	}
+
+	return &model.ReviewConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}
+}
+
+func newRevisionConnection(revisions []dto.RevisionResponse, meta *utils.PaginationMeta) *model.RevisionConnection {
+	edges := make([]*model.RevisionEdge, len(revisions))
+	for i := range revisions {
+		edges[i] = &model.RevisionEdge{
+			Node: &revisions[i],
+		}
+	}
+
+	return &model.RevisionConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}
+}
+
+func newStockMovementConnection(movements []dto.StockMovementResponse, meta *utils.PaginationMeta) *model.StockMovementConnection {
+	edges := make([]*model.StockMovementEdge, len(movements))
+	for i := range movements {
+		edges[i] = &model.StockMovementEdge{
+			Node: &movements[i],
+		}
+	}
+
+	return &model.StockMovementConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}
+}
+
+// jsonValue encodes an arbitrary value for String fields that carry JSON
+func jsonValue(value interface{}) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := string(data)
+	return &encoded, nil
+}
+
+func optionalID(id *uint) *string {
+	if id == nil {
+		return nil
+	}
+
+	formatted := fmt.Sprintf("%d", *id)
+	return &formatted
+}
+
+func warehouseStockList(stock []dto.WarehouseStockResponse) []*dto.WarehouseStockResponse {
+	result := make([]*dto.WarehouseStockResponse, len(stock))
+	for i := range stock {
+		result[i] = &stock[i]
+	}
+
+	return result
+}
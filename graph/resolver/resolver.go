@@ -1,8 +1,10 @@
 package resolver
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/joefazee/learning-go-shop/internal/dto"
 	"github.com/joefazee/learning-go-shop/internal/services"
 )
 
@@ -11,27 +13,65 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	authService    services.AuthServiceInterface
-	userService    services.UserServiceInterface
-	productService services.ProductServiceInterface
-	cartService    services.CartServiceInterface
-	orderService   services.OrderServiceInterface
+	authService        services.AuthServiceInterface
+	userService        services.UserServiceInterface
+	productService     services.ProductServiceInterface
+	cartService        services.CartServiceInterface
+	orderService       services.OrderServiceInterface
+	reviewService      services.ReviewServiceInterface
+	revisionService    services.RevisionServiceInterface
+	currencyService    services.CurrencyServiceInterface
+	translationService services.TranslationServiceInterface
+	warehouseService   services.WarehouseServiceInterface
+
+	stockSubscriptionService services.StockSubscriptionServiceInterface
+	uploadService            services.UploadServiceInterface
 }
 
 func NewResolver(authService services.AuthServiceInterface,
 	userService services.UserServiceInterface,
 	productService services.ProductServiceInterface,
 	cartService services.CartServiceInterface,
-	orderService services.OrderServiceInterface) *Resolver {
+	orderService services.OrderServiceInterface,
+	reviewService services.ReviewServiceInterface,
+	revisionService services.RevisionServiceInterface,
+	currencyService services.CurrencyServiceInterface,
+	translationService services.TranslationServiceInterface,
+	warehouseService services.WarehouseServiceInterface,
+	stockSubscriptionService services.StockSubscriptionServiceInterface,
+	uploadService services.UploadServiceInterface) *Resolver {
 
 	return &Resolver{
-		authService:    authService,
-		userService:    userService,
-		productService: productService,
-		cartService:    cartService,
-		orderService:   orderService,
+		authService:        authService,
+		userService:        userService,
+		productService:     productService,
+		cartService:        cartService,
+		orderService:       orderService,
+		reviewService:      reviewService,
+		revisionService:    revisionService,
+		currencyService:    currencyService,
+		translationService: translationService,
+		warehouseService:   warehouseService,
+
+		stockSubscriptionService: stockSubscriptionService,
+		uploadService:            uploadService,
+	}
+
+}
+
+// localization returns the currency and locale resolved for the request, with
+// the locale argument of a query taking precedence over Accept-Language
+func (r *Resolver) localization(ctx context.Context, locale *string) (dto.Localization, error) {
+	l10n := GetLocalizationFromContext(ctx)
+	if locale != nil {
+		resolved, err := r.translationService.ResolveLocale(*locale)
+		if err != nil {
+			return l10n, err
+		}
+		l10n.Locale = resolved
 	}
 
+	return l10n, nil
 }
 
 func (r *Resolver) parseID(id string) (uint, error) {
@@ -8,9 +8,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/joefazee/learning-go-shop/graph"
 	"github.com/joefazee/learning-go-shop/graph/model"
 	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
 )
 
 // Register is the resolver for the register field.
@@ -74,7 +76,12 @@ func (r *mutationResolver) CreateCategory(ctx context.Context, input dto.CreateC
 		return nil, ErrUnauthorized
 	}
 
-	category, err := r.productService.CreateCategory(&input)
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	category, err := r.productService.CreateCategory(actorID, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
@@ -88,12 +95,17 @@ func (r *mutationResolver) UpdateCategory(ctx context.Context, id string, input
 		return nil, ErrUnauthorized
 	}
 
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
 	categoryID, err := r.parseID(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid category ID: %w", err)
 	}
 
-	category, err := r.productService.UpdateCategory(categoryID, &input)
+	category, err := r.productService.UpdateCategory(actorID, categoryID, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
@@ -107,12 +119,17 @@ func (r *mutationResolver) DeleteCategory(ctx context.Context, id string) (bool,
 		return false, ErrUnauthorized
 	}
 
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
 	categoryID, err := r.parseID(id)
 	if err != nil {
 		return false, fmt.Errorf("invalid category ID: %w", err)
 	}
 
-	err = r.productService.DeleteCategory(categoryID)
+	err = r.productService.DeleteCategory(actorID, categoryID)
 	if err != nil {
 		return false, fmt.Errorf("failed to delete category: %w", err)
 	}
@@ -126,7 +143,12 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input dto.CreatePr
 		return nil, ErrUnauthorized
 	}
 
-	product, err := r.productService.CreateProduct(&input)
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	product, err := r.productService.CreateProduct(actorID, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
@@ -140,12 +162,17 @@ func (r *mutationResolver) UpdateProduct(ctx context.Context, id string, input d
 		return nil, ErrUnauthorized
 	}
 
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
 	productID, err := r.parseID(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	product, err := r.productService.UpdateProduct(productID, &input)
+	product, err := r.productService.UpdateProduct(actorID, productID, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
@@ -159,12 +186,17 @@ func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (bool,
 		return false, ErrUnauthorized
 	}
 
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
 	productID, err := r.parseID(id)
 	if err != nil {
 		return false, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	err = r.productService.DeleteProduct(productID)
+	err = r.productService.DeleteProduct(actorID, productID)
 	if err != nil {
 		return false, fmt.Errorf("failed to delete product: %w", err)
 	}
@@ -172,208 +204,1146 @@ func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (bool,
 	return true, nil
 }
 
-// AddToCart is the resolver for the addToCart field.
-func (r *mutationResolver) AddToCart(ctx context.Context, input dto.AddToCartRequest) (*dto.CartResponse, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// SetProductBundle is the resolver for the setProductBundle field.
+func (r *mutationResolver) SetProductBundle(ctx context.Context, id string, input dto.SetBundleRequest) (*dto.ProductResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	actorID, err := GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, ErrUnauthorized
 	}
 
-	cart, err := r.cartService.AddToCart(userID, &input)
+	productID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := r.productService.SetBundle(actorID, productID, &input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add to cart: %w", err)
+		return nil, fmt.Errorf("failed to update bundle: %w", err)
 	}
 
-	return cart, nil
+	return product, nil
 }
 
-// UpdateCartItem is the resolver for the updateCartItem field.
-func (r *mutationResolver) UpdateCartItem(ctx context.Context, id string, input dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// RemoveProductBundle is the resolver for the removeProductBundle field.
+func (r *mutationResolver) RemoveProductBundle(ctx context.Context, id string) (*dto.ProductResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	actorID, err := GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, ErrUnauthorized
 	}
 
-	itemID, err := r.parseID(id)
+	productID, err := r.parseID(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid item ID: %w", err)
+		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	cart, err := r.cartService.UpdateCartItem(userID, itemID, &input)
+	product, err := r.productService.RemoveBundle(actorID, productID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update cart item: %w", err)
+		return nil, fmt.Errorf("failed to remove bundle: %w", err)
 	}
 
-	return cart, nil
+	return product, nil
 }
 
-// RemoveFromCart is the resolver for the removeFromCart field.
-func (r *mutationResolver) RemoveFromCart(ctx context.Context, id string) (bool, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// UploadProductImage is the resolver for the uploadProductImage field.
+func (r *mutationResolver) UploadProductImage(ctx context.Context, productID string, file graphql.Upload, altText *string) (*dto.ProductImageResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	pid, err := r.parseID(productID)
 	if err != nil {
-		return false, ErrUnauthorized
+		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	itemID, err := r.parseID(id)
+	uploaded, err := r.uploadService.UploadProductImageFrom(pid, file.Filename, file.Size, file.File)
 	if err != nil {
-		return false, fmt.Errorf("invalid item ID: %w", err)
+		return nil, fmt.Errorf("failed to upload image: %w", err)
 	}
 
-	err = r.cartService.RemoveFromCart(userID, itemID)
+	var alt string
+	if altText != nil {
+		alt = *altText
+	}
+
+	image, err := r.productService.AddProductImage(pid, uploaded, alt)
 	if err != nil {
-		return false, fmt.Errorf("failed to remove from cart: %w", err)
+		return nil, fmt.Errorf("failed to save image: %w", err)
 	}
 
-	return true, nil
+	return image, nil
 }
 
-// CreateOrder is the resolver for the createOrder field.
-func (r *mutationResolver) CreateOrder(ctx context.Context) (*dto.OrderResponse, error) {
-	userID, err := GetUserIDFromContext(ctx)
-	if err != nil {
+// ImportProductImage is the resolver for the importProductImage field.
+func (r *mutationResolver) ImportProductImage(ctx context.Context, productID string, input dto.ImportProductImageRequest) (*dto.ProductImageResponse, error) {
+	if !IsAdminFromContext(ctx) {
 		return nil, ErrUnauthorized
 	}
 
-	order, err := r.orderService.CreateOrder(userID)
+	pid, err := r.parseID(productID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	return order, nil
+	uploaded, err := r.uploadService.ImportProductImage(pid, input.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import image: %w", err)
+	}
+
+	image, err := r.productService.AddProductImage(pid, uploaded, input.AltText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	return image, nil
 }
 
-// Me is the resolver for the me field.
-func (r *queryResolver) Me(ctx context.Context) (*dto.UserResponse, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// UpdateProductImage is the resolver for the updateProductImage field.
+func (r *mutationResolver) UpdateProductImage(ctx context.Context, productID string, id string, input dto.UpdateProductImageRequest) (*dto.ProductImageResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	pid, err := r.parseID(productID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	user, err := r.userService.GetProfile(userID)
+	imageID, err := r.parseID(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user profile: %w", err)
+		return nil, fmt.Errorf("invalid image ID: %w", err)
 	}
 
-	return user, nil
+	image, err := r.productService.UpdateProductImage(pid, imageID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update image: %w", err)
+	}
+
+	return image, nil
 }
 
-// Products is the resolver for the products field.
-func (r *queryResolver) Products(ctx context.Context, page *int, limit *int) (*model.ProductConnection, error) {
-	p, l := getPagingNumbers(page, limit)
+// DeleteProductImage is the resolver for the deleteProductImage field.
+func (r *mutationResolver) DeleteProductImage(ctx context.Context, productID string, id string) (bool, error) {
+	if !IsAdminFromContext(ctx) {
+		return false, ErrUnauthorized
+	}
 
-	products, meta, err := r.productService.GetProducts(p, l)
+	pid, err := r.parseID(productID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get products: %w", err)
+		return false, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	edges := make([]*model.ProductEdge, len(products)) // allocate enough memory for all the products
-	for i, product := range products {
-		edges[i] = &model.ProductEdge{
-			Node: &product,
-		}
+	imageID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid image ID: %w", err)
 	}
 
-	return &model.ProductConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			Page:       meta.Page,
-			Limit:      meta.Limit,
-			Total:      int(meta.Total),
-			TotalPages: meta.TotalPages,
-		},
-	}, nil
+	paths, err := r.productService.DeleteProductImage(pid, imageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	r.uploadService.DeleteFiles(paths)
+
+	return true, nil
 }
 
-// Product is the resolver for the product field.
-func (r *queryResolver) Product(ctx context.Context, id string) (*dto.ProductResponse, error) {
-	productID, err := r.parseID(id)
+// ReorderProductImages is the resolver for the reorderProductImages field.
+func (r *mutationResolver) ReorderProductImages(ctx context.Context, productID string, imageIds []string) ([]*dto.ProductImageResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	pid, err := r.parseID(productID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	product, err := r.productService.GetProduct(productID)
+	ids := make([]uint, len(imageIds))
+	for i, imageID := range imageIds {
+		if ids[i], err = r.parseID(imageID); err != nil {
+			return nil, fmt.Errorf("invalid image ID: %w", err)
+		}
+	}
+
+	images, err := r.productService.ReorderProductImages(pid, ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get product: %w", err)
+		return nil, fmt.Errorf("failed to reorder images: %w", err)
 	}
 
-	return product, nil
+	result := make([]*dto.ProductImageResponse, len(images))
+	for i := range images {
+		result[i] = &images[i]
+	}
+
+	return result, nil
 }
 
-// Categories is the resolver for the categories field.
-func (r *queryResolver) Categories(ctx context.Context) ([]*dto.CategoryResponse, error) {
-	categories, err := r.productService.GetCategories()
+// SetPrimaryProductImage is the resolver for the setPrimaryProductImage field.
+func (r *mutationResolver) SetPrimaryProductImage(ctx context.Context, productID string, id string) ([]*dto.ProductImageResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	pid, err := r.parseID(productID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get categories: %w", err)
+		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	result := make([]*dto.CategoryResponse, len(categories))
-	for i, cat := range categories {
-		result[i] = &cat
+	imageID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	images, err := r.productService.SetPrimaryProductImage(pid, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set primary image: %w", err)
+	}
+
+	result := make([]*dto.ProductImageResponse, len(images))
+	for i := range images {
+		result[i] = &images[i]
 	}
 
 	return result, nil
 }
 
-// Cart is the resolver for the cart field.
-func (r *queryResolver) Cart(ctx context.Context) (*dto.CartResponse, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// RollbackRevision is the resolver for the rollbackRevision field.
+func (r *mutationResolver) RollbackRevision(ctx context.Context, id string) (*dto.RevisionResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	actorID, err := GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, ErrUnauthorized
 	}
 
-	cart, err := r.cartService.GetCart(userID)
+	revisionID, err := r.parseID(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cart: %w", err)
+		return nil, fmt.Errorf("invalid revision ID: %w", err)
 	}
 
-	return cart, nil
+	revision, err := r.revisionService.Rollback(actorID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back revision: %w", err)
+	}
+
+	return revision, nil
 }
 
-// Orders is the resolver for the orders field.
-func (r *queryResolver) Orders(ctx context.Context, page *int, limit *int) (*model.OrderConnection, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// SetExchangeRate is the resolver for the setExchangeRate field.
+func (r *mutationResolver) SetExchangeRate(ctx context.Context, currency string, input dto.SetExchangeRateRequest) (*dto.CurrencyResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	rate, err := r.currencyService.SetExchangeRate(currency, &input)
 	if err != nil {
+		return nil, fmt.Errorf("failed to set exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// DeleteExchangeRate is the resolver for the deleteExchangeRate field.
+func (r *mutationResolver) DeleteExchangeRate(ctx context.Context, currency string) (bool, error) {
+	if !IsAdminFromContext(ctx) {
+		return false, ErrUnauthorized
+	}
+
+	if err := r.currencyService.DeleteExchangeRate(currency); err != nil {
+		return false, fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetProductPrice is the resolver for the setProductPrice field.
+func (r *mutationResolver) SetProductPrice(ctx context.Context, productID string, currency string, input dto.SetProductPriceRequest) (*dto.ProductPriceResponse, error) {
+	if !IsAdminFromContext(ctx) {
 		return nil, ErrUnauthorized
 	}
 
-	p, l := getPagingNumbers(page, limit)
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
 
-	orders, meta, err := r.orderService.GetOrders(userID, p, l)
+	price, err := r.currencyService.SetProductPrice(id, currency, &input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get orders: %w", err)
+		return nil, fmt.Errorf("failed to set product price: %w", err)
 	}
 
-	edges := make([]*model.OrderEdge, len(orders))
-	for i, order := range orders {
-		edges[i] = &model.OrderEdge{
-			Node: &order,
-		}
+	return price, nil
+}
+
+// DeleteProductPrice is the resolver for the deleteProductPrice field.
+func (r *mutationResolver) DeleteProductPrice(ctx context.Context, productID string, currency string) (bool, error) {
+	if !IsAdminFromContext(ctx) {
+		return false, ErrUnauthorized
 	}
 
-	return &model.OrderConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			Page:       meta.Page,
-			Limit:      meta.Limit,
-			Total:      int(meta.Total),
-			TotalPages: meta.TotalPages,
-		},
-	}, nil
+	id, err := r.parseID(productID)
+	if err != nil {
+		return false, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	if err := r.currencyService.DeleteProductPrice(id, currency); err != nil {
+		return false, fmt.Errorf("failed to delete product price: %w", err)
+	}
+
+	return true, nil
 }
 
-// Order is the resolver for the order field.
-func (r *queryResolver) Order(ctx context.Context, id string) (*dto.OrderResponse, error) {
-	userID, err := GetUserIDFromContext(ctx)
+// SetProductTranslation is the resolver for the setProductTranslation field.
+func (r *mutationResolver) SetProductTranslation(ctx context.Context, productID string, locale string, input dto.SetTranslationRequest) (*dto.TranslationResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	translation, err := r.translationService.SetProductTranslation(id, locale, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set product translation: %w", err)
+	}
+
+	return translation, nil
+}
+
+// DeleteProductTranslation is the resolver for the deleteProductTranslation field.
+func (r *mutationResolver) DeleteProductTranslation(ctx context.Context, productID string, locale string) (bool, error) {
+	if !IsAdminFromContext(ctx) {
+		return false, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
 	if err != nil {
+		return false, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	if err := r.translationService.DeleteProductTranslation(id, locale); err != nil {
+		return false, fmt.Errorf("failed to delete product translation: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetCategoryTranslation is the resolver for the setCategoryTranslation field.
+func (r *mutationResolver) SetCategoryTranslation(ctx context.Context, categoryID string, locale string, input dto.SetTranslationRequest) (*dto.TranslationResponse, error) {
+	if !IsAdminFromContext(ctx) {
 		return nil, ErrUnauthorized
 	}
 
-	orderID, err := r.parseID(id)
+	id, err := r.parseID(categoryID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid order ID: %w", err)
+		return nil, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	translation, err := r.translationService.SetCategoryTranslation(id, locale, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set category translation: %w", err)
+	}
+
+	return translation, nil
+}
+
+// DeleteCategoryTranslation is the resolver for the deleteCategoryTranslation field.
+func (r *mutationResolver) DeleteCategoryTranslation(ctx context.Context, categoryID string, locale string) (bool, error) {
+	if !IsAdminFromContext(ctx) {
+		return false, ErrUnauthorized
+	}
+
+	id, err := r.parseID(categoryID)
+	if err != nil {
+		return false, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	if err := r.translationService.DeleteCategoryTranslation(id, locale); err != nil {
+		return false, fmt.Errorf("failed to delete category translation: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateWarehouse is the resolver for the createWarehouse field.
+func (r *mutationResolver) CreateWarehouse(ctx context.Context, input dto.WarehouseRequest) (*dto.WarehouseResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	warehouse, err := r.warehouseService.CreateWarehouse(&input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create warehouse: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+// UpdateWarehouse is the resolver for the updateWarehouse field.
+func (r *mutationResolver) UpdateWarehouse(ctx context.Context, id string, input dto.WarehouseRequest) (*dto.WarehouseResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	warehouseID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	warehouse, err := r.warehouseService.UpdateWarehouse(warehouseID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update warehouse: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+// DeleteWarehouse is the resolver for the deleteWarehouse field.
+func (r *mutationResolver) DeleteWarehouse(ctx context.Context, id string) (bool, error) {
+	if !IsAdminFromContext(ctx) {
+		return false, ErrUnauthorized
+	}
+
+	warehouseID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid warehouse ID: %w", err)
+	}
+
+	if err := r.warehouseService.DeleteWarehouse(warehouseID); err != nil {
+		return false, fmt.Errorf("failed to delete warehouse: %w", err)
+	}
+
+	return true, nil
+}
+
+// AdjustStock is the resolver for the adjustStock field.
+func (r *mutationResolver) AdjustStock(ctx context.Context, productID string, input dto.AdjustStockRequest) ([]*dto.WarehouseStockResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := r.warehouseService.AdjustStock(userID, id, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+
+	return warehouseStockList(stock), nil
+}
+
+// TransferStock is the resolver for the transferStock field.
+func (r *mutationResolver) TransferStock(ctx context.Context, productID string, input dto.TransferStockRequest) ([]*dto.WarehouseStockResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := r.warehouseService.TransferStock(userID, id, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer stock: %w", err)
+	}
+
+	return warehouseStockList(stock), nil
+}
+
+// AddToCart is the resolver for the addToCart field.
+func (r *mutationResolver) AddToCart(ctx context.Context, input dto.AddToCartRequest) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	cart, err := r.cartService.AddToCart(userID, &input, GetLocalizationFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add to cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// UpdateCartItem is the resolver for the updateCartItem field.
+func (r *mutationResolver) UpdateCartItem(ctx context.Context, id string, input dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	itemID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid item ID: %w", err)
+	}
+
+	cart, err := r.cartService.UpdateCartItem(userID, itemID, &input, GetLocalizationFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cart item: %w", err)
+	}
+
+	return cart, nil
+}
+
+// RemoveFromCart is the resolver for the removeFromCart field.
+func (r *mutationResolver) RemoveFromCart(ctx context.Context, id string) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	itemID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid item ID: %w", err)
+	}
+
+	err = r.cartService.RemoveFromCart(userID, itemID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove from cart: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReserveCart is the resolver for the reserveCart field.
+func (r *mutationResolver) ReserveCart(ctx context.Context) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	cart, err := r.cartService.ReserveCart(userID, GetLocalizationFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// ReleaseCart is the resolver for the releaseCart field.
+func (r *mutationResolver) ReleaseCart(ctx context.Context) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	if err := r.cartService.ReleaseCart(userID); err != nil {
+		return false, fmt.Errorf("failed to release reservation: %w", err)
+	}
+
+	return true, nil
+}
+
+// SubscribeToStock is the resolver for the subscribeToStock field.
+func (r *mutationResolver) SubscribeToStock(ctx context.Context, productID string) (*dto.StockSubscriptionResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	subscription, err := r.stockSubscriptionService.Subscribe(userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// CancelStockSubscription is the resolver for the cancelStockSubscription field.
+func (r *mutationResolver) CancelStockSubscription(ctx context.Context, id string) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	subscriptionID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	if err := r.stockSubscriptionService.CancelSubscription(userID, subscriptionID); err != nil {
+		return false, fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateOrder is the resolver for the createOrder field.
+func (r *mutationResolver) CreateOrder(ctx context.Context, input *dto.CreateOrderRequest) (*dto.OrderResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	order, err := r.orderService.CreateOrder(userID, input, GetLocalizationFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	return order, nil
+}
+
+// SubmitReview is the resolver for the submitReview field.
+func (r *mutationResolver) SubmitReview(ctx context.Context, productID string, input dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	review, err := r.reviewService.CreateReview(userID, id, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	return review, nil
+}
+
+// ModerateReview is the resolver for the moderateReview field.
+func (r *mutationResolver) ModerateReview(ctx context.Context, id string, input dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	reviewID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review ID: %w", err)
+	}
+
+	review, err := r.reviewService.ModerateReview(reviewID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	return review, nil
+}
+
+// MarkReviewHelpful is the resolver for the markReviewHelpful field.
+func (r *mutationResolver) MarkReviewHelpful(ctx context.Context, id string) (*dto.ReviewResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	reviewID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review ID: %w", err)
+	}
+
+	review, err := r.reviewService.MarkHelpful(userID, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark review helpful: %w", err)
+	}
+
+	return review, nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*dto.UserResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userService.GetProfile(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	return user, nil
+}
+
+// Products is the resolver for the products field.
+func (r *queryResolver) Products(ctx context.Context, page *int, limit *int, sort *string, locale *string) (*model.ProductConnection, error) {
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	req := &dto.ListProductsRequest{Page: p, Limit: l, Localization: l10n}
+	if sort != nil {
+		req.Sort = *sort
+	}
+
+	products, meta, err := r.productService.GetProducts(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	edges := make([]*model.ProductEdge, len(products)) // allocate enough memory for all the products
+	for i, product := range products {
+		edges[i] = &model.ProductEdge{
+			Node: &product,
+		}
+	}
+
+	return &model.ProductConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}, nil
+}
+
+// Product is the resolver for the product field.
+func (r *queryResolver) Product(ctx context.Context, id string, locale *string) (*dto.ProductResponse, error) {
+	productID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := r.productService.GetProduct(productID, l10n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// SearchSuggestions is the resolver for the searchSuggestions field.
+func (r *queryResolver) SearchSuggestions(ctx context.Context, query string, limit *int, locale *string) (*dto.SearchSuggestionsResponse, error) {
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &dto.SearchSuggestRequest{Query: query, Localization: l10n}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	suggestions, err := r.productService.SuggestSearch(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// ProductReviews is the resolver for the productReviews field.
+func (r *queryResolver) ProductReviews(ctx context.Context, productID string, page *int, limit *int, sort *string) (*model.ReviewConnection, error) {
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	req := &dto.ListReviewsRequest{Page: p, Limit: l}
+	if sort != nil {
+		req.Sort = *sort
+	}
+
+	reviews, meta, err := r.reviewService.GetProductReviews(id, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	return newReviewConnection(reviews, meta), nil
+}
+
+// ReviewQueue is the resolver for the reviewQueue field.
+func (r *queryResolver) ReviewQueue(ctx context.Context, status *string, page *int, limit *int) (*model.ReviewConnection, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	reviewStatus := ""
+	if status != nil {
+		reviewStatus = *status
+	}
+
+	reviews, meta, err := r.reviewService.GetReviewsByStatus(reviewStatus, p, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	return newReviewConnection(reviews, meta), nil
+}
+
+// ProductRevisions is the resolver for the productRevisions field.
+func (r *queryResolver) ProductRevisions(ctx context.Context, productID string, page *int, limit *int) (*model.RevisionConnection, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	revisions, meta, err := r.revisionService.GetRevisions(services.RevisionEntityProduct, id, p, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	return newRevisionConnection(revisions, meta), nil
+}
+
+// CategoryRevisions is the resolver for the categoryRevisions field.
+func (r *queryResolver) CategoryRevisions(ctx context.Context, categoryID string, page *int, limit *int) (*model.RevisionConnection, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	revisions, meta, err := r.revisionService.GetRevisions(services.RevisionEntityCategory, id, p, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	return newRevisionConnection(revisions, meta), nil
+}
+
+// Revision is the resolver for the revision field.
+func (r *queryResolver) Revision(ctx context.Context, id string) (*dto.RevisionResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	revisionID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid revision ID: %w", err)
+	}
+
+	revision, err := r.revisionService.GetRevision(revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context, locale *string) ([]*dto.CategoryResponse, error) {
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := r.productService.GetCategories(l10n.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	result := make([]*dto.CategoryResponse, len(categories))
+	for i, cat := range categories {
+		result[i] = &cat
+	}
+
+	return result, nil
+}
+
+// Currencies is the resolver for the currencies field.
+func (r *queryResolver) Currencies(ctx context.Context) ([]*dto.CurrencyResponse, error) {
+	currencies, err := r.currencyService.GetCurrencies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currencies: %w", err)
+	}
+
+	result := make([]*dto.CurrencyResponse, len(currencies))
+	for i := range currencies {
+		result[i] = &currencies[i]
+	}
+
+	return result, nil
+}
+
+// ProductPrices is the resolver for the productPrices field.
+func (r *queryResolver) ProductPrices(ctx context.Context, productID string) ([]*dto.ProductPriceResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	prices, err := r.currencyService.GetProductPrices(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product prices: %w", err)
+	}
+
+	result := make([]*dto.ProductPriceResponse, len(prices))
+	for i := range prices {
+		result[i] = &prices[i]
+	}
+
+	return result, nil
+}
+
+// ProductTranslations is the resolver for the productTranslations field.
+func (r *queryResolver) ProductTranslations(ctx context.Context, productID string) ([]*dto.TranslationResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	translations, err := r.translationService.GetProductTranslations(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product translations: %w", err)
+	}
+
+	result := make([]*dto.TranslationResponse, len(translations))
+	for i := range translations {
+		result[i] = &translations[i]
+	}
+
+	return result, nil
+}
+
+// CategoryTranslations is the resolver for the categoryTranslations field.
+func (r *queryResolver) CategoryTranslations(ctx context.Context, categoryID string) ([]*dto.TranslationResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	translations, err := r.translationService.GetCategoryTranslations(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category translations: %w", err)
+	}
+
+	result := make([]*dto.TranslationResponse, len(translations))
+	for i := range translations {
+		result[i] = &translations[i]
+	}
+
+	return result, nil
+}
+
+// Warehouses is the resolver for the warehouses field.
+func (r *queryResolver) Warehouses(ctx context.Context) ([]*dto.WarehouseResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	warehouses, err := r.warehouseService.GetWarehouses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warehouses: %w", err)
+	}
+
+	result := make([]*dto.WarehouseResponse, len(warehouses))
+	for i := range warehouses {
+		result[i] = &warehouses[i]
+	}
+
+	return result, nil
+}
+
+// ProductStock is the resolver for the productStock field.
+func (r *queryResolver) ProductStock(ctx context.Context, productID string) ([]*dto.WarehouseStockResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	stock, err := r.warehouseService.GetProductStock(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product stock: %w", err)
+	}
+
+	return warehouseStockList(stock), nil
+}
+
+// StockMovements is the resolver for the stockMovements field.
+func (r *queryResolver) StockMovements(ctx context.Context, productID string, page *int, limit *int) (*model.StockMovementConnection, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	movements, meta, err := r.warehouseService.GetStockMovements(id, p, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movements: %w", err)
+	}
+
+	return newStockMovementConnection(movements, meta), nil
+}
+
+// StockReconciliation is the resolver for the stockReconciliation field.
+func (r *queryResolver) StockReconciliation(ctx context.Context) (*dto.StockReconciliationResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	reconciliation, err := r.warehouseService.ReconcileStock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile stock: %w", err)
+	}
+
+	return reconciliation, nil
+}
+
+// Cart is the resolver for the cart field.
+func (r *queryResolver) Cart(ctx context.Context, locale *string) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := r.cartService.GetCart(userID, l10n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// StockSubscriptions is the resolver for the stockSubscriptions field.
+func (r *queryResolver) StockSubscriptions(ctx context.Context) ([]*dto.StockSubscriptionResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	subscriptions, err := r.stockSubscriptionService.GetSubscriptions(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	result := make([]*dto.StockSubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		result[i] = &subscriptions[i]
+	}
+
+	return result, nil
+}
+
+// Orders is the resolver for the orders field.
+func (r *queryResolver) Orders(ctx context.Context, page *int, limit *int, locale *string) (*model.OrderConnection, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	orders, meta, err := r.orderService.GetOrders(userID, p, l, l10n.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	edges := make([]*model.OrderEdge, len(orders))
+	for i, order := range orders {
+		edges[i] = &model.OrderEdge{
+			Node: &order,
+		}
+	}
+
+	return &model.OrderConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}, nil
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, id string, locale *string) (*dto.OrderResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	l10n, err := r.localization(ctx, locale)
+	if err != nil {
+		return nil, err
 	}
 
-	order, err := r.orderService.GetOrder(userID, orderID)
+	order, err := r.orderService.GetOrder(userID, orderID, l10n.Locale)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
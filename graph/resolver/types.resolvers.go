@@ -6,12 +6,18 @@ package resolver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/joefazee/learning-go-shop/graph"
 	"github.com/joefazee/learning-go-shop/internal/dto"
 )
 
+// ProductID is the resolver for the product_id field.
+func (r *bundleItemResolver) ProductID(ctx context.Context, obj *dto.BundleItemResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *cartResolver) ID(ctx context.Context, obj *dto.CartResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -32,6 +38,16 @@ func (r *categoryResolver) ID(ctx context.Context, obj *dto.CategoryResponse) (s
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// From is the resolver for the from field.
+func (r *fieldChangeResolver) From(ctx context.Context, obj *dto.FieldChange) (*string, error) {
+	return jsonValue(obj.From)
+}
+
+// To is the resolver for the to field.
+func (r *fieldChangeResolver) To(ctx context.Context, obj *dto.FieldChange) (*string, error) {
+	return jsonValue(obj.To)
+}
+
 // ID is the resolver for the id field.
 func (r *orderResolver) ID(ctx context.Context, obj *dto.OrderResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -47,6 +63,21 @@ func (r *orderItemResolver) ID(ctx context.Context, obj *dto.OrderItemResponse)
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// WarehouseID is the resolver for the warehouse_id field.
+func (r *orderItemAllocationResolver) WarehouseID(ctx context.Context, obj *dto.OrderItemAllocationResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.WarehouseID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *orderItemAllocationResolver) ProductID(ctx context.Context, obj *dto.OrderItemAllocationResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *orderItemComponentResolver) ProductID(ctx context.Context, obj *dto.OrderItemComponentResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *productResolver) ID(ctx context.Context, obj *dto.ProductResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -62,11 +93,119 @@ func (r *productImageResolver) ID(ctx context.Context, obj *dto.ProductImageResp
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// ProductID is the resolver for the product_id field.
+func (r *productPriceResolver) ProductID(ctx context.Context, obj *dto.ProductPriceResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *reviewResolver) ID(ctx context.Context, obj *dto.ReviewResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *reviewResolver) ProductID(ctx context.Context, obj *dto.ReviewResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// UserID is the resolver for the user_id field.
+func (r *reviewResolver) UserID(ctx context.Context, obj *dto.ReviewResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.UserID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *revisionResolver) ID(ctx context.Context, obj *dto.RevisionResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// EntityID is the resolver for the entity_id field.
+func (r *revisionResolver) EntityID(ctx context.Context, obj *dto.RevisionResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.EntityID), nil
+}
+
+// ActorID is the resolver for the actor_id field.
+func (r *revisionResolver) ActorID(ctx context.Context, obj *dto.RevisionResponse) (*string, error) {
+	return optionalID(obj.ActorID), nil
+}
+
+// RollbackOfID is the resolver for the rollback_of_id field.
+func (r *revisionResolver) RollbackOfID(ctx context.Context, obj *dto.RevisionResponse) (*string, error) {
+	return optionalID(obj.RollbackOfID), nil
+}
+
+// Snapshot is the resolver for the snapshot field.
+func (r *revisionResolver) Snapshot(ctx context.Context, obj *dto.RevisionResponse) (string, error) {
+	snapshot, err := json.Marshal(obj.Snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	return string(snapshot), nil
+}
+
+// ID is the resolver for the id field.
+func (r *searchSuggestionResolver) ID(ctx context.Context, obj *dto.SearchSuggestion) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *stockDiscrepancyResolver) ProductID(ctx context.Context, obj *dto.StockDiscrepancy) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// WarehouseID is the resolver for the warehouse_id field.
+func (r *stockDiscrepancyResolver) WarehouseID(ctx context.Context, obj *dto.StockDiscrepancy) (*string, error) {
+	return optionalID(obj.WarehouseID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *stockMovementResolver) ID(ctx context.Context, obj *dto.StockMovementResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *stockMovementResolver) ProductID(ctx context.Context, obj *dto.StockMovementResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// WarehouseID is the resolver for the warehouse_id field.
+func (r *stockMovementResolver) WarehouseID(ctx context.Context, obj *dto.StockMovementResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.WarehouseID), nil
+}
+
+// ActorID is the resolver for the actor_id field.
+func (r *stockMovementResolver) ActorID(ctx context.Context, obj *dto.StockMovementResponse) (*string, error) {
+	return optionalID(obj.ActorID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *stockSubscriptionResolver) ID(ctx context.Context, obj *dto.StockSubscriptionResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *stockSubscriptionResolver) ProductID(ctx context.Context, obj *dto.StockSubscriptionResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *userResolver) ID(ctx context.Context, obj *dto.UserResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// ID is the resolver for the id field.
+func (r *warehouseResolver) ID(ctx context.Context, obj *dto.WarehouseResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// WarehouseID is the resolver for the warehouse_id field.
+func (r *warehouseStockResolver) WarehouseID(ctx context.Context, obj *dto.WarehouseStockResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.WarehouseID), nil
+}
+
+// BundleItem returns graph.BundleItemResolver implementation.
+func (r *Resolver) BundleItem() graph.BundleItemResolver { return &bundleItemResolver{r} }
+
 // Cart returns graph.CartResolver implementation.
 func (r *Resolver) Cart() graph.CartResolver { return &cartResolver{r} }
 
@@ -76,26 +215,85 @@ func (r *Resolver) CartItem() graph.CartItemResolver { return &cartItemResolver{
 // Category returns graph.CategoryResolver implementation.
 func (r *Resolver) Category() graph.CategoryResolver { return &categoryResolver{r} }
 
+// FieldChange returns graph.FieldChangeResolver implementation.
+func (r *Resolver) FieldChange() graph.FieldChangeResolver { return &fieldChangeResolver{r} }
+
 // Order returns graph.OrderResolver implementation.
 func (r *Resolver) Order() graph.OrderResolver { return &orderResolver{r} }
 
 // OrderItem returns graph.OrderItemResolver implementation.
 func (r *Resolver) OrderItem() graph.OrderItemResolver { return &orderItemResolver{r} }
 
+// OrderItemAllocation returns graph.OrderItemAllocationResolver implementation.
+func (r *Resolver) OrderItemAllocation() graph.OrderItemAllocationResolver {
+	return &orderItemAllocationResolver{r}
+}
+
+// OrderItemComponent returns graph.OrderItemComponentResolver implementation.
+func (r *Resolver) OrderItemComponent() graph.OrderItemComponentResolver {
+	return &orderItemComponentResolver{r}
+}
+
 // Product returns graph.ProductResolver implementation.
 func (r *Resolver) Product() graph.ProductResolver { return &productResolver{r} }
 
 // ProductImage returns graph.ProductImageResolver implementation.
 func (r *Resolver) ProductImage() graph.ProductImageResolver { return &productImageResolver{r} }
 
+// ProductPrice returns graph.ProductPriceResolver implementation.
+func (r *Resolver) ProductPrice() graph.ProductPriceResolver { return &productPriceResolver{r} }
+
+// Review returns graph.ReviewResolver implementation.
+func (r *Resolver) Review() graph.ReviewResolver { return &reviewResolver{r} }
+
+// Revision returns graph.RevisionResolver implementation.
+func (r *Resolver) Revision() graph.RevisionResolver { return &revisionResolver{r} }
+
+// SearchSuggestion returns graph.SearchSuggestionResolver implementation.
+func (r *Resolver) SearchSuggestion() graph.SearchSuggestionResolver {
+	return &searchSuggestionResolver{r}
+}
+
+// StockDiscrepancy returns graph.StockDiscrepancyResolver implementation.
+func (r *Resolver) StockDiscrepancy() graph.StockDiscrepancyResolver {
+	return &stockDiscrepancyResolver{r}
+}
+
+// StockMovement returns graph.StockMovementResolver implementation.
+func (r *Resolver) StockMovement() graph.StockMovementResolver { return &stockMovementResolver{r} }
+
+// StockSubscription returns graph.StockSubscriptionResolver implementation.
+func (r *Resolver) StockSubscription() graph.StockSubscriptionResolver {
+	return &stockSubscriptionResolver{r}
+}
+
 // User returns graph.UserResolver implementation.
 func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
 
+// Warehouse returns graph.WarehouseResolver implementation.
+func (r *Resolver) Warehouse() graph.WarehouseResolver { return &warehouseResolver{r} }
+
+// WarehouseStock returns graph.WarehouseStockResolver implementation.
+func (r *Resolver) WarehouseStock() graph.WarehouseStockResolver { return &warehouseStockResolver{r} }
+
+type bundleItemResolver struct{ *Resolver }
 type cartResolver struct{ *Resolver }
 type cartItemResolver struct{ *Resolver }
 type categoryResolver struct{ *Resolver }
+type fieldChangeResolver struct{ *Resolver }
 type orderResolver struct{ *Resolver }
 type orderItemResolver struct{ *Resolver }
+type orderItemAllocationResolver struct{ *Resolver }
+type orderItemComponentResolver struct{ *Resolver }
 type productResolver struct{ *Resolver }
 type productImageResolver struct{ *Resolver }
+type productPriceResolver struct{ *Resolver }
+type reviewResolver struct{ *Resolver }
+type revisionResolver struct{ *Resolver }
+type searchSuggestionResolver struct{ *Resolver }
+type stockDiscrepancyResolver struct{ *Resolver }
+type stockMovementResolver struct{ *Resolver }
+type stockSubscriptionResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
+type warehouseResolver struct{ *Resolver }
+type warehouseStockResolver struct{ *Resolver }
@@ -1,25 +1,39 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	AWS      AWSConfig
-	Upload   UploadConfig
-	SMTP     SMTPConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	AWS         AWSConfig
+	Upload      UploadConfig
+	SMTP        SMTPConfig
+	Download    DownloadConfig
+	Trash       TrashConfig
+	Store       StoreConfig
+	Stock       StockConfig
+	Warehouse   WarehouseConfig
+	LowStock    LowStockConfig
+	BackInStock BackInStockConfig
+	Search      SearchConfig
 }
 
 type ServerConfig struct {
 	Port    string
 	GinMode string
+
+	// PublicURL is the externally reachable base URL used in links sent to users
+	PublicURL string
 }
 
 type DatabaseConfig struct {
@@ -41,6 +55,7 @@ type AWSConfig struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	S3Bucket        string
+	S3PrivateBucket string
 	S3Endpoint      string
 	EventQueueName  string
 }
@@ -55,12 +70,109 @@ type SMTPConfig struct {
 
 type UploadConfig struct {
 	Path        string
+	PrivatePath string
 	MaxFileSize int64
 
+	// Images larger than these are rejected before they are decoded
+	MaxImageWidth  int
+	MaxImageHeight int
+	MaxImagePixels int64
+
+	// PresignTTL is how long a presigned direct upload URL is valid
+	PresignTTL time.Duration
+
+	// CDNBaseURL is where public files are served from, such as the nginx
+	// CDN. Local files default to PUBLIC_URL/uploads and S3 files to the
+	// bucket's own URL.
+	CDNBaseURL string
+	// SignURLs serves public files from a private bucket with signed URLs,
+	// valid for SignedURLTTL
+	SignURLs     bool
+	SignedURLTTL time.Duration
+
+	// ImportTimeout bounds fetching an image to import from a URL
+	ImportTimeout time.Duration
+
+	// Unreferenced files older than GCGracePeriod are deleted every GCInterval
+	GCGracePeriod time.Duration
+	GCInterval    time.Duration
+
 	// UploadProvider  can be s3 or local
 	UploadProvider string
 }
 
+type DownloadConfig struct {
+	SigningSecret string
+	LinkTTL       time.Duration
+	MaxDownloads  int
+}
+
+type TrashConfig struct {
+	// RetentionPeriod is how long soft-deleted rows stay restorable before the worker purges them
+	RetentionPeriod time.Duration
+	PurgeInterval   time.Duration
+}
+
+type StoreConfig struct {
+	// BaseCurrency is the ISO 4217 code product prices are stored in
+	BaseCurrency string
+
+	// Locales are the languages catalog content can be translated into. The
+	// default locale (en) is always supported.
+	Locales []string
+}
+
+type StockConfig struct {
+	// ReservationTTL is how long stock held for a checkout stays reserved
+	ReservationTTL time.Duration
+
+	// ReserveOnAddToCart also holds stock while items sit in a cart, not just
+	// once checkout begins
+	ReserveOnAddToCart bool
+
+	// ReservationSweepInterval is how often the worker releases expired reservations
+	ReservationSweepInterval time.Duration
+
+	// LowStockThreshold is the on-hand stock at or below which a product is
+	// low on stock, unless the product sets its own. 0 turns alerts off.
+	LowStockThreshold int
+}
+
+type WarehouseConfig struct {
+	// AllocationStrategy picks the warehouses an order ships from: priority,
+	// closest or split
+	AllocationStrategy string
+}
+
+type LowStockConfig struct {
+	// Recipients are the staff emailed when products run low on stock
+	Recipients []string
+
+	// Digest collects alerts into one email sent every DigestInterval instead
+	// of emailing each alert as it arrives
+	Digest         bool
+	DigestInterval time.Duration
+}
+
+type BackInStockConfig struct {
+	// BatchSize is the most subscribers notified by a single event
+	BatchSize int
+
+	// BatchInterval is how long the notifier waits between batches of
+	// back-in-stock emails
+	BatchInterval time.Duration
+}
+
+type SearchConfig struct {
+	// FuzzyThreshold is the number of full-text matches below which a search
+	// also returns products with similar names or SKUs. 0 turns it off.
+	FuzzyThreshold int
+
+	// VocabularyRefreshInterval is how often the worker refreshes the words
+	// misspelled searches are corrected to
+	VocabularyRefreshInterval time.Duration
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
@@ -68,11 +180,52 @@ func Load() (*Config, error) {
 	refreshTokenExpires, _ := time.ParseDuration(getEnv("REFRESH_TOKEN_EXPIRES_IN", "720h"))
 	maxUploadSize, _ := strconv.ParseInt(getEnv("MAX_UPLOAD_SIZE", "10485760"), 10, 64)
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "1025"))
+	downloadLinkTTL, _ := time.ParseDuration(getEnv("DOWNLOAD_LINK_TTL", "24h"))
+	maxDownloads, _ := strconv.Atoi(getEnv("DOWNLOAD_MAX_COUNT", "5"))
+	trashRetention, _ := time.ParseDuration(getEnv("TRASH_RETENTION_PERIOD", "720h"))
+	reservationTTL, _ := time.ParseDuration(getEnv("STOCK_RESERVATION_TTL", "15m"))
+	reserveOnAddToCart, _ := strconv.ParseBool(getEnv("STOCK_RESERVE_ON_ADD_TO_CART", "false"))
+	lowStockThreshold, _ := strconv.Atoi(getEnv("STOCK_LOW_THRESHOLD", "5"))
+	lowStockDigest, _ := strconv.ParseBool(getEnv("LOW_STOCK_DIGEST", "false"))
+	backInStockBatchSize, _ := strconv.Atoi(getEnv("BACK_IN_STOCK_BATCH_SIZE", "50"))
+	backInStockBatchInterval, _ := time.ParseDuration(getEnv("BACK_IN_STOCK_BATCH_INTERVAL", "30s"))
+	maxImageWidth, _ := strconv.Atoi(getEnv("MAX_IMAGE_WIDTH", "8000"))
+	maxImageHeight, _ := strconv.Atoi(getEnv("MAX_IMAGE_HEIGHT", "8000"))
+	maxImagePixels, _ := strconv.ParseInt(getEnv("MAX_IMAGE_PIXELS", "40000000"), 10, 64)
+	uploadPresignTTL, _ := time.ParseDuration(getEnv("UPLOAD_PRESIGN_TTL", "15m"))
+	uploadSignURLs, _ := strconv.ParseBool(getEnv("UPLOAD_SIGN_URLS", "false"))
+	uploadSignedURLTTL, _ := time.ParseDuration(getEnv("UPLOAD_SIGNED_URL_TTL", "1h"))
+	uploadImportTimeout, _ := time.ParseDuration(getEnv("UPLOAD_IMPORT_TIMEOUT", "10s"))
+	uploadGCGracePeriod, _ := time.ParseDuration(getEnv("UPLOAD_GC_GRACE_PERIOD", "24h"))
+	searchFuzzyThreshold, _ := strconv.Atoi(getEnv("SEARCH_FUZZY_THRESHOLD", "3"))
+
+	// Job and digest intervals drive tickers, so they must be positive
+	trashPurgeInterval, err := getInterval("TRASH_PURGE_INTERVAL", "1h")
+	if err != nil {
+		return nil, err
+	}
+	reservationSweepInterval, err := getInterval("STOCK_RESERVATION_SWEEP_INTERVAL", "1m")
+	if err != nil {
+		return nil, err
+	}
+	uploadGCInterval, err := getInterval("UPLOAD_GC_INTERVAL", "6h")
+	if err != nil {
+		return nil, err
+	}
+	searchVocabularyRefreshInterval, err := getInterval("SEARCH_VOCABULARY_REFRESH_INTERVAL", "1h")
+	if err != nil {
+		return nil, err
+	}
+	lowStockDigestInterval, err := getInterval("LOW_STOCK_DIGEST_INTERVAL", "24h")
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:      getEnv("PORT", "8080"),
+			GinMode:   getEnv("GIN_MODE", "debug"),
+			PublicURL: getEnv("PUBLIC_URL", "http://localhost:8080"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -92,12 +245,24 @@ func Load() (*Config, error) {
 			AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", "test"),
 			SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", "test"),
 			S3Bucket:        getEnv("AWS_S3_BUCKET", "ecommerce-uploads"),
+			S3PrivateBucket: getEnv("AWS_S3_PRIVATE_BUCKET", "ecommerce-private"),
 			S3Endpoint:      getEnv("AWS_S3_ENDPOINT", "http://localhost:4566"),
 			EventQueueName:  getEnv("AWS_EVENT_QUEUE_NAME", "ecommerce-events"),
 		},
 		Upload: UploadConfig{
 			Path:           getEnv("UPLOAD_PATH", "./uploads"),
+			PrivatePath:    getEnv("PRIVATE_UPLOAD_PATH", "./storage/private"),
 			MaxFileSize:    maxUploadSize,
+			MaxImageWidth:  maxImageWidth,
+			MaxImageHeight: maxImageHeight,
+			MaxImagePixels: maxImagePixels,
+			PresignTTL:     uploadPresignTTL,
+			CDNBaseURL:     getEnv("UPLOAD_CDN_URL", ""),
+			SignURLs:       uploadSignURLs,
+			SignedURLTTL:   uploadSignedURLTTL,
+			ImportTimeout:  uploadImportTimeout,
+			GCGracePeriod:  uploadGCGracePeriod,
+			GCInterval:     uploadGCInterval,
 			UploadProvider: getEnv("UPLOAD_PROVIDER", "local"),
 		},
 		SMTP: SMTPConfig{
@@ -107,10 +272,80 @@ func Load() (*Config, error) {
 			Password: getEnv("SMTP_PASSWORD", ""),
 			From:     getEnv("SMTP_FROM", "noreply@shop.com"),
 		},
+		Download: DownloadConfig{
+			SigningSecret: getEnv("DOWNLOAD_SIGNING_SECRET", "your-download-signing-secret"),
+			LinkTTL:       downloadLinkTTL,
+			MaxDownloads:  maxDownloads,
+		},
+		Trash: TrashConfig{
+			RetentionPeriod: trashRetention,
+			PurgeInterval:   trashPurgeInterval,
+		},
+		Store: StoreConfig{
+			BaseCurrency: strings.ToUpper(getEnv("STORE_BASE_CURRENCY", "USD")),
+			Locales:      parseLocales(getEnv("STORE_LOCALES", "en,fr,de")),
+		},
+		Stock: StockConfig{
+			ReservationTTL:           reservationTTL,
+			ReserveOnAddToCart:       reserveOnAddToCart,
+			ReservationSweepInterval: reservationSweepInterval,
+			LowStockThreshold:        lowStockThreshold,
+		},
+		Warehouse: WarehouseConfig{
+			AllocationStrategy: strings.ToLower(getEnv("WAREHOUSE_ALLOCATION_STRATEGY", "priority")),
+		},
+		LowStock: LowStockConfig{
+			Recipients:     parseList(getEnv("LOW_STOCK_ALERT_RECIPIENTS", "")),
+			Digest:         lowStockDigest,
+			DigestInterval: lowStockDigestInterval,
+		},
+		BackInStock: BackInStockConfig{
+			BatchSize:     backInStockBatchSize,
+			BatchInterval: backInStockBatchInterval,
+		},
+		Search: SearchConfig{
+			FuzzyThreshold:            searchFuzzyThreshold,
+			VocabularyRefreshInterval: searchVocabularyRefreshInterval,
+		},
 	}, nil
 
 }
 
+func parseLocales(value string) []string {
+	locales := []string{"en"}
+	for _, locale := range strings.Split(value, ",") {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+
+	return locales
+}
+
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// getInterval reads a duration that must be greater than zero
+func getInterval(key, defaultValue string) (time.Duration, error) {
+	value := getEnv(key, defaultValue)
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as %q, got %q", key, defaultValue, value)
+	}
+
+	return interval, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -9,10 +9,15 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// trigramSimilarityThreshold is the similarity pg_trgm's % operator needs,
+// used to correct misspelled search words. It is below the default of 0.3
+// because transposed letters, as in "iphnoe", leave few trigrams in common.
+const trigramSimilarityThreshold = "0.2"
+
 func New(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port, cfg.SSLMode,
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC pg_trgm.similarity_threshold=%s",
+		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port, cfg.SSLMode, trigramSimilarityThreshold,
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
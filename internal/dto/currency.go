@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/money"
+)
+
+type CurrencyResponse struct {
+	Code      string     `json:"code"`
+	Rate      float64    `json:"rate"`
+	IsBase    bool       `json:"is_base"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type SetExchangeRateRequest struct {
+	Rate float64 `json:"rate" binding:"required,gt=0"`
+}
+
+type ProductPriceResponse struct {
+	ProductID uint        `json:"product_id"`
+	Currency  string      `json:"currency"`
+	Price     money.Money `json:"price" swaggertype:"number"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type SetProductPriceRequest struct {
+	Price money.Money `json:"price" binding:"required,gt=0" swaggertype:"number"`
+}
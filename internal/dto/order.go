@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/money"
+)
 
 type AddToCartRequest struct {
 	ProductID uint `json:"product_id" binding:"required"`
@@ -11,38 +15,85 @@ type UpdateCartItemRequest struct {
 	Quantity int `json:"quantity" binding:"required,min=1"`
 }
 
+// CreateOrderRequest optionally gives the shipping location, used to ship
+// from the closest warehouses
+type CreateOrderRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"omitempty,required_with=Longitude,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,required_with=Latitude,min=-180,max=180"`
+}
+
 type CartResponse struct {
 	ID        uint               `json:"id"`
 	UserID    uint               `json:"user_id"`
 	CartItems []CartItemResponse `json:"cart_items"`
-	Total     float64            `json:"total"`
+	Total     money.Money        `json:"total" swaggertype:"number"`
+	Currency  string             `json:"currency"`
 	CreatedAt time.Time          `json:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at"`
+
+	// ReservedUntil is when the stock held for checkout is released, if any
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"`
 }
 
 type CartItemResponse struct {
 	ID        uint            `json:"id"`
 	Product   ProductResponse `json:"product"`
 	Quantity  int             `json:"quantity"`
-	Subtotal  float64         `json:"subtotal"`
+	Subtotal  money.Money     `json:"subtotal" swaggertype:"number"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
 type OrderResponse struct {
-	ID          uint                `json:"id"`
-	UserID      uint                `json:"user_id"`
-	Status      string              `json:"status"`
-	TotalAmount float64             `json:"total_amount"`
-	OrderItems  []OrderItemResponse `json:"order_items"`
-	CreatedAt   time.Time           `json:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at"`
+	ID               uint                `json:"id"`
+	UserID           uint                `json:"user_id"`
+	Status           string              `json:"status"`
+	TotalAmount      money.Money         `json:"total_amount" swaggertype:"number"`
+	Currency         string              `json:"currency"`
+	ExchangeRate     float64             `json:"exchange_rate"`
+	RequiresShipping bool                `json:"requires_shipping"`
+	OrderItems       []OrderItemResponse `json:"order_items"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
 }
 
 type OrderItemResponse struct {
-	ID        uint            `json:"id"`
-	Product   ProductResponse `json:"product"`
-	Quantity  int             `json:"quantity"`
-	Price     float64         `json:"price"`
-	CreatedAt time.Time       `json:"created_at"`
+	ID          uint                          `json:"id"`
+	Product     ProductResponse               `json:"product"`
+	Quantity    int                           `json:"quantity"`
+	Price       money.Money                   `json:"price" swaggertype:"number"`
+	Components  []OrderItemComponentResponse  `json:"components,omitempty"`
+	Allocations []OrderItemAllocationResponse `json:"allocations"`
+	CreatedAt   time.Time                     `json:"created_at"`
+
+	// Backordered is the units still waiting for stock, expected to ship on
+	// ExpectedShipDate when the product gave one
+	Backordered      int        `json:"backordered"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date,omitempty"`
+}
+
+// OrderItemAllocationResponse is the warehouse units of an order line ship
+// from. For bundles the product is the component.
+type OrderItemAllocationResponse struct {
+	WarehouseID   uint   `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	ProductID     uint   `json:"product_id"`
+	Quantity      int    `json:"quantity"`
+}
+
+type OrderItemComponentResponse struct {
+	ProductID uint   `json:"product_id"`
+	Name      string `json:"name"`
+	SKU       string `json:"sku"`
+	Quantity  int    `json:"quantity"`
+}
+
+type DownloadLinkResponse struct {
+	ProductID          uint      `json:"product_id"`
+	ProductName        string    `json:"product_name"`
+	FileName           string    `json:"file_name"`
+	Size               int64     `json:"size"`
+	URL                string    `json:"url"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	DownloadsRemaining int       `json:"downloads_remaining"`
 }
@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/money"
+)
 
 type CreateCategoryRequest struct {
 	Name        string `json:"name" binding:"required"`
@@ -23,56 +27,190 @@ type CategoryResponse struct {
 }
 
 type CreateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	SKU         string  `json:"sku" binding:"required"`
+	CategoryID  uint        `json:"category_id" binding:"required"`
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" binding:"required,gt=0" swaggertype:"number"`
+	Stock       int         `json:"stock" binding:"min=0"`
+	SKU         string      `json:"sku" binding:"required"`
+	IsDigital   bool        `json:"is_digital"`
+
+	// LowStockThreshold overrides the store-wide low-stock threshold, 0 turns
+	// alerts off for the product
+	LowStockThreshold *int `json:"low_stock_threshold" binding:"omitempty,min=0"`
+
+	// StockPolicy allows selling beyond stock: deny (the default), backorder
+	// or preorder. BackorderLimit caps the units owed to customers, 0 for no
+	// limit. Pre-orders need an ExpectedShipDate.
+	StockPolicy      string     `json:"stock_policy" binding:"omitempty,oneof=deny backorder preorder"`
+	BackorderLimit   int        `json:"backorder_limit" binding:"min=0"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date"`
 }
 
 type UpdateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	IsActive    *bool   `json:"is_active"`
+	CategoryID  uint        `json:"category_id" binding:"required"`
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" binding:"required,gt=0" swaggertype:"number"`
+	IsActive    *bool       `json:"is_active"`
+	IsDigital   *bool       `json:"is_digital"`
+
+	// LowStockThreshold overrides the store-wide low-stock threshold, 0 turns
+	// alerts off for the product and null uses the store-wide threshold again
+	LowStockThreshold *int `json:"low_stock_threshold" binding:"omitempty,min=0"`
+
+	// StockPolicy allows selling beyond stock: deny (the default), backorder
+	// or preorder. BackorderLimit caps the units owed to customers, 0 for no
+	// limit. Pre-orders need an ExpectedShipDate.
+	StockPolicy      string     `json:"stock_policy" binding:"omitempty,oneof=deny backorder preorder"`
+	BackorderLimit   int        `json:"backorder_limit" binding:"min=0"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date"`
 }
 
 type ProductResponse struct {
-	ID          uint                   `json:"id"`
-	CategoryID  uint                   `json:"category_id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Price       float64                `json:"price"`
-	Stock       int                    `json:"stock"`
-	SKU         string                 `json:"sku"`
-	IsActive    bool                   `json:"is_active"`
-	Category    CategoryResponse       `json:"category"`
-	Images      []ProductImageResponse `json:"images"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	ID               uint                   `json:"id"`
+	CategoryID       uint                   `json:"category_id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	Price            money.Money            `json:"price" swaggertype:"number"`
+	Currency         string                 `json:"currency"`
+	Stock            int                    `json:"stock"`
+	SKU              string                 `json:"sku"`
+	IsActive         bool                   `json:"is_active"`
+	IsDigital        bool                   `json:"is_digital"`
+	RatingAverage    float64                `json:"rating_average"`
+	RatingCount      int                    `json:"rating_count"`
+	IsBundle         bool                   `json:"is_bundle"`
+	BundlePricing    string                 `json:"bundle_pricing,omitempty"`
+	BundlePercentage float64                `json:"bundle_percentage,omitempty"`
+	BundleItems      []BundleItemResponse   `json:"bundle_items,omitempty"`
+	Category         CategoryResponse       `json:"category"`
+	Images           []ProductImageResponse `json:"images"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+
+	// OnHandStock is what is physically in stock, AvailableStock is that less
+	// the units held by checkouts. Stock equals AvailableStock.
+	AvailableStock int `json:"available_stock"`
+	OnHandStock    int `json:"on_hand_stock"`
+
+	LowStockThreshold *int `json:"low_stock_threshold,omitempty"`
+
+	// Availability is in_stock, backorder, preorder or out_of_stock. Backorders
+	// and pre-orders are expected to ship on ExpectedShipDate when it is given.
+	StockPolicy      string     `json:"stock_policy"`
+	BackorderLimit   int        `json:"backorder_limit,omitempty"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date,omitempty"`
+	Availability     string     `json:"availability"`
+}
+
+type BundleItemResponse struct {
+	ProductID uint        `json:"product_id"`
+	Name      string      `json:"name"`
+	SKU       string      `json:"sku"`
+	Price     money.Money `json:"price" swaggertype:"number"`
+	Quantity  int         `json:"quantity"`
+}
+
+type SetBundleRequest struct {
+	Pricing    string              `json:"pricing" binding:"required,oneof=fixed percentage"`
+	Percentage float64             `json:"percentage" binding:"omitempty,gt=0,lte=100"`
+	Items      []BundleItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type BundleItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Quantity  int  `json:"quantity" binding:"required,min=1"`
 }
 
 type ProductImageResponse struct {
-	ID        uint      `json:"id"`
-	URL       string    `json:"url"`
-	AltText   string    `json:"alt_text"`
-	IsPrimary bool      `json:"is_primary"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         uint                            `json:"id"`
+	URL        string                          `json:"url"`
+	AltText    string                          `json:"alt_text"`
+	IsPrimary  bool                            `json:"is_primary"`
+	Position   int                             `json:"position"`
+	Renditions []ProductImageRenditionResponse `json:"renditions"`
+	CreatedAt  time.Time                       `json:"created_at"`
+}
+
+type UpdateProductImageRequest struct {
+	AltText string `json:"alt_text" binding:"max=255"`
+}
+
+// ReorderProductImagesRequest lists every image of a product in the order
+// they should be shown
+type ReorderProductImagesRequest struct {
+	ImageIDs []uint `json:"image_ids" binding:"required"`
+}
+
+// ProductImageRenditionResponse is a resized version of a product image.
+// Name is thumbnail, medium or large and Format is jpeg, png or webp.
+type ProductImageRenditionResponse struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type ProductFileResponse struct {
+	ID          uint      `json:"id"`
+	ProductID   uint      `json:"product_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ListProductsRequest struct {
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	Sort  string `form:"sort" binding:"omitempty,oneof=newest rating"`
+
+	// Localization is resolved by the currency and locale middlewares
+	Localization Localization `form:"-"`
 }
 
 type SearchProductsRequest struct {
-	Query      string   `form:"q" binding:"required,min=1"`
-	Page       int      `form:"page"`
-	Limit      int      `form:"limit"`
-	CategoryID *uint    `form:"category_id"`
-	MinPrice   *float64 `form:"min_price"`
-	MaxPrice   *float64 `form:"max_price"`
+	Query      string       `form:"q" binding:"required,min=1"`
+	Page       int          `form:"page"`
+	Limit      int          `form:"limit"`
+	CategoryID *uint        `form:"category_id"`
+	MinPrice   *money.Money `form:"min_price"`
+	MaxPrice   *money.Money `form:"max_price"`
+
+	// Localization is resolved by the currency and locale middlewares. Price
+	// filters are in its currency.
+	Localization Localization `form:"-"`
 }
 
+// ProductSearchResult is a product found by a search. A search that finds few
+// products also returns products with similar names or SKUs, flagged as fuzzy.
 type ProductSearchResult struct {
 	ProductResponse
-	Rank float32 `json:"rank"`
+	Rank  float32 `json:"rank"`
+	Fuzzy bool    `json:"fuzzy"`
+}
+
+type SearchSuggestRequest struct {
+	Query string `form:"q" binding:"required,min=1,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20"`
+
+	// Localization is resolved by the locale middleware
+	Localization Localization `form:"-"`
+}
+
+// SearchSuggestionsResponse holds typeahead suggestions for a partial query,
+// each kind ordered from the best match
+type SearchSuggestionsResponse struct {
+	Products   []SearchSuggestion `json:"products"`
+	Categories []SearchSuggestion `json:"categories"`
+	Queries    []string           `json:"queries"`
+}
+
+// SearchSuggestion is a product or category suggested by name, in the
+// requested locale
+type SearchSuggestion struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
 }
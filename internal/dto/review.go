@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Title  string `json:"title" binding:"max=255"`
+	Body   string `json:"body" binding:"required,max=5000"`
+}
+
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+}
+
+type ListReviewsRequest struct {
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	Sort  string `form:"sort" binding:"omitempty,oneof=newest helpful rating_desc rating_asc"`
+}
+
+type ReviewResponse struct {
+	ID                 uint      `json:"id"`
+	ProductID          uint      `json:"product_id"`
+	UserID             uint      `json:"user_id"`
+	AuthorName         string    `json:"author_name"`
+	Rating             int       `json:"rating"`
+	Title              string    `json:"title"`
+	Body               string    `json:"body"`
+	IsVerifiedPurchase bool      `json:"is_verified_purchase"`
+	Status             string    `json:"status"`
+	HelpfulCount       int       `json:"helpful_count"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type RevisionResponse struct {
+	ID           uint                   `json:"id"`
+	EntityType   string                 `json:"entity_type"`
+	EntityID     uint                   `json:"entity_id"`
+	Version      int                    `json:"version"`
+	Action       string                 `json:"action"`
+	ActorID      *uint                  `json:"actor_id"`
+	ActorEmail   string                 `json:"actor_email,omitempty"`
+	RollbackOfID *uint                  `json:"rollback_of_id,omitempty"`
+	Changes      []FieldChange          `json:"changes"`
+	Snapshot     map[string]interface{} `json:"snapshot"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type CreateStockSubscriptionRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+}
+
+type StockSubscriptionResponse struct {
+	ID          uint      `json:"id"`
+	ProductID   uint      `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	SKU         string    `json:"sku"`
+	CreatedAt   time.Time `json:"created_at"`
+}
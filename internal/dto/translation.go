@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// Localization selects how catalog content is presented: the currency prices
+// are shown in and the locale names and descriptions are shown in. Empty
+// fields select the store defaults.
+type Localization struct {
+	Currency string
+	Locale   string
+}
+
+type TranslationResponse struct {
+	Locale      string    `json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SetTranslationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type ListTrashRequest struct {
+	Type  string `form:"type" binding:"omitempty,oneof=product category"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+type RestoreCategoryRequest struct {
+	// Cascade also restores the products that were trashed together with the category
+	Cascade bool `form:"cascade"`
+}
+
+type TrashItemResponse struct {
+	ID         uint      `json:"id"`
+	Type       string    `json:"type"`
+	Name       string    `json:"name"`
+	SKU        string    `json:"sku,omitempty"`
+	CategoryID uint      `json:"category_id,omitempty"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// PresignImageUploadRequest describes an image the client wants to upload
+// directly to storage. The upload must match it exactly.
+type PresignImageUploadRequest struct {
+	Filename    string `json:"filename" binding:"required"`
+	ContentType string `json:"content_type" binding:"required"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+}
+
+// PresignedUploadResponse is where and how to upload the file. The request
+// must be sent with all of Headers, before ExpiresAt.
+type PresignedUploadResponse struct {
+	UploadID  uint              `json:"upload_id"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ImportProductImageRequest adds an image fetched from URL to a product
+type ImportProductImageRequest struct {
+	URL     string `json:"url" binding:"required,url"`
+	AltText string `json:"alt_text"`
+}
+
+type ConfirmImageUploadRequest struct {
+	UploadID uint   `json:"upload_id" binding:"required"`
+	AltText  string `json:"alt_text"`
+}
@@ -0,0 +1,84 @@
+package dto
+
+import "time"
+
+type WarehouseResponse struct {
+	ID        uint      `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	Priority  int       `json:"priority"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WarehouseRequest struct {
+	Code      string   `json:"code" binding:"required,max=32"`
+	Name      string   `json:"name" binding:"required"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Priority  int      `json:"priority"`
+	IsDefault bool     `json:"is_default"`
+}
+
+type WarehouseStockResponse struct {
+	WarehouseID   uint      `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+	WarehouseName string    `json:"warehouse_name"`
+	Quantity      int       `json:"quantity"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AdjustStockRequest changes the stock at a warehouse by Quantity, which is
+// negative to remove units. Restocks and returns add units, damage removes
+// them and adjustments do either. Reference is free text such as a delivery
+// note or RMA number.
+type AdjustStockRequest struct {
+	WarehouseID uint   `json:"warehouse_id" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required"`
+	Reason      string `json:"reason" binding:"required,oneof=restock adjustment return damage"`
+	Reference   string `json:"reference" binding:"max=255"`
+}
+
+type TransferStockRequest struct {
+	FromWarehouseID uint   `json:"from_warehouse_id" binding:"required"`
+	ToWarehouseID   uint   `json:"to_warehouse_id" binding:"required,nefield=FromWarehouseID"`
+	Quantity        int    `json:"quantity" binding:"required,min=1"`
+	Reference       string `json:"reference" binding:"max=255"`
+}
+
+type StockMovementResponse struct {
+	ID            uint      `json:"id"`
+	ProductID     uint      `json:"product_id"`
+	WarehouseID   uint      `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+	Quantity      int       `json:"quantity"`
+	Reason        string    `json:"reason"`
+	ActorID       *uint     `json:"actor_id,omitempty"`
+	ActorEmail    string    `json:"actor_email,omitempty"`
+	Reference     string    `json:"reference"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StockReconciliationResponse compares the stock ledger with current stock.
+// Only mismatches are listed.
+type StockReconciliationResponse struct {
+	CheckedAt     time.Time          `json:"checked_at"`
+	Checked       int                `json:"checked"`
+	Discrepancies []StockDiscrepancy `json:"discrepancies"`
+}
+
+// StockDiscrepancy is a stock level that differs from the sum of its ledger
+// movements. Without a warehouse it is the product's total stock.
+type StockDiscrepancy struct {
+	ProductID      uint   `json:"product_id"`
+	ProductName    string `json:"product_name"`
+	SKU            string `json:"sku"`
+	WarehouseID    *uint  `json:"warehouse_id,omitempty"`
+	WarehouseCode  string `json:"warehouse_code,omitempty"`
+	LedgerQuantity int    `json:"ledger_quantity"`
+	StockQuantity  int    `json:"stock_quantity"`
+	Difference     int    `json:"difference"`
+}
@@ -0,0 +1,183 @@
+// Package imaging turns uploaded product images into the renditions the
+// storefront serves.
+//
+// Every upload is decoded and encoded again, which drops EXIF and any other
+// metadata, after rotating it upright as its EXIF orientation asks. The
+// cleaned original is kept at full size next to a fixed set of smaller sizes,
+// each also encoded as WebP. The WebP encoder is lossless so builds stay free
+// of cgo.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	_ "image/gif"
+
+	"github.com/HugoSmits86/nativewebp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Rendition names. Original is the cleaned upload at its own size.
+const (
+	Original  = "original"
+	Thumbnail = "thumbnail"
+	Medium    = "medium"
+	Large     = "large"
+)
+
+// Formats renditions are encoded in
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+const jpegQuality = 85
+
+// Size is a rendition scaled to fit within MaxDimension on its longest side
+type Size struct {
+	Name         string
+	MaxDimension int
+}
+
+// Sizes are the renditions made of every image besides the original. Images
+// are never scaled up.
+var Sizes = []Size{
+	{Name: Thumbnail, MaxDimension: 150},
+	{Name: Medium, MaxDimension: 600},
+	{Name: Large, MaxDimension: 1200},
+}
+
+var ErrUnsupportedImage = errors.New("unsupported or corrupt image")
+
+// Rendition is an encoded version of an image
+type Rendition struct {
+	Name   string
+	Format string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// ContentType is the MIME type of the rendition's data
+func (r *Rendition) ContentType() string {
+	return "image/" + r.Format
+}
+
+// Ext is the file extension for the rendition's format
+func (r *Rendition) Ext() string {
+	if r.Format == FormatJPEG {
+		return ".jpg"
+	}
+
+	return "." + r.Format
+}
+
+// Process decodes an image and returns its renditions: the original in its
+// own format family, then each of Sizes in that format and as WebP. Images
+// with transparency are kept as PNG, everything else becomes JPEG.
+func Process(r io.Reader) ([]Rendition, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	img = orient(img, exifOrientation(data))
+
+	format := FormatJPEG
+	if !isOpaque(img) {
+		format = FormatPNG
+	}
+
+	original, err := encode(Original, img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	renditions := []Rendition{original}
+	for _, size := range Sizes {
+		scaled := fit(img, size.MaxDimension)
+
+		for _, f := range []string{format, FormatWebP} {
+			rendition, err := encode(size.Name, scaled, f)
+			if err != nil {
+				return nil, err
+			}
+
+			renditions = append(renditions, rendition)
+		}
+	}
+
+	return renditions, nil
+}
+
+func encode(name string, img image.Image, format string) (Rendition, error) {
+	var buf bytes.Buffer
+
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	case FormatWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = fmt.Errorf("unknown image format %q", format)
+	}
+
+	if err != nil {
+		return Rendition{}, fmt.Errorf("unable to encode %s %s: %w", name, format, err)
+	}
+
+	bounds := img.Bounds()
+	return Rendition{
+		Name:   name,
+		Format: format,
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		Data:   buf.Bytes(),
+	}, nil
+}
+
+// fit scales an image down to fit within maxDimension on its longest side
+func fit(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return img
+	}
+
+	if width >= height {
+		height = max(height*maxDimension/width, 1)
+		width = maxDimension
+	} else {
+		width = max(width*maxDimension/height, 1)
+		height = maxDimension
+	}
+
+	scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), img, bounds, draw.Src, nil)
+	return scaled
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	return false
+}
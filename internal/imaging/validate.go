@@ -0,0 +1,74 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+)
+
+// FormatGIF is accepted on upload but never produced
+const FormatGIF = "gif"
+
+// ErrImageTooLarge is returned for images whose dimensions exceed the limits
+var ErrImageTooLarge = errors.New("image dimensions exceed the limit")
+
+// Limits bound the dimensions of images accepted for processing
+type Limits struct {
+	MaxWidth  int
+	MaxHeight int
+	MaxPixels int64
+}
+
+// Info describes an image as read from its header
+type Info struct {
+	Format string
+	Width  int
+	Height int
+}
+
+// Sniff returns the image format named by the magic bytes at the start of
+// data, or an empty string if it is not an image format we accept.
+func Sniff(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return FormatGIF
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return FormatWebP
+	}
+
+	return ""
+}
+
+// Inspect checks that data is an image we accept and that its dimensions are
+// within limits. Only the header is decoded, so a small file claiming huge
+// dimensions is rejected before any memory is allocated for its pixels.
+func Inspect(data []byte, limits Limits) (*Info, error) {
+	format := Sniff(data)
+	if format == "" {
+		return nil, ErrUnsupportedImage
+	}
+
+	config, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if decoded != format {
+		return nil, fmt.Errorf("%w: %s header in %s data", ErrUnsupportedImage, decoded, format)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("%w: empty image", ErrUnsupportedImage)
+	}
+
+	if (limits.MaxWidth > 0 && config.Width > limits.MaxWidth) ||
+		(limits.MaxHeight > 0 && config.Height > limits.MaxHeight) ||
+		(limits.MaxPixels > 0 && int64(config.Width)*int64(config.Height) > limits.MaxPixels) {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	return &Info{Format: format, Width: config.Width, Height: config.Height}, nil
+}
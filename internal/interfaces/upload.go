@@ -1,8 +1,96 @@
 package interfaces
 
-import "mime/multipart"
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+)
 
+var (
+	// ErrObjectNotFound is returned by Stat when nothing is stored at a path
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrNoPublicURL is returned by URL for files that are never served directly
+	ErrNoPublicURL = errors.New("files are not publicly served")
+	// ErrSizeMismatch is returned by Put when the content is not the size given
+	ErrSizeMismatch = errors.New("content size does not match")
+)
+
+// UploadProvider stores files and serves them back. Paths are keys relative to
+// the provider's root, such as products/1/uuid.jpg.
 type UploadProvider interface {
-	UploadFile(file *multipart.FileHeader, path string) (string, error)
+	// Put stores everything read from r at path and returns its key
+	Put(path string, r io.Reader, opts PutOptions) (string, error)
+	Open(path string) (io.ReadCloser, error)
+	Stat(path string) (*ObjectInfo, error)
+	// List returns every file stored under prefix
+	List(prefix string) ([]StoredObject, error)
 	DeleteFile(path string) error
+	// URL returns the absolute URL clients fetch a stored file from
+	URL(path string) (string, error)
+}
+
+// PutOptions describe the content being stored
+type PutOptions struct {
+	ContentType string
+	// Size is the length of the content, or -1 if it is not known. A known
+	// size is checked against what was read.
+	Size int64
+	// CacheControl is sent with the file where the provider serves it
+	CacheControl string
+	// Metadata is kept with the file where the provider supports it
+	Metadata map[string]string
+}
+
+// ImmutableCacheControl lets clients and CDNs cache a file forever, for files
+// whose key changes whenever their content does
+const ImmutableCacheControl = "public, max-age=31536000, immutable"
+
+// ContentAddressedPrefix is where files named by the SHA-256 of their content
+// are stored, so the only public files that are served as immutable
+const ContentAddressedPrefix = "products/sha256/"
+
+// PutMultipart stores a file uploaded in a multipart form
+func PutMultipart(provider UploadProvider, file *multipart.FileHeader, path string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	return provider.Put(path, src, PutOptions{
+		ContentType: file.Header.Get("Content-Type"),
+		Size:        file.Size,
+	})
+}
+
+// StoredObject is a file found by List
+type StoredObject struct {
+	Key        string
+	Size       int64
+	ModifiedAt time.Time
+}
+
+// DirectUploadProvider is implemented by providers that let clients upload
+// straight to storage, without the file passing through the API
+type DirectUploadProvider interface {
+	// PresignUpload returns a request that stores exactly size bytes of
+	// contentType at path until it expires
+	PresignUpload(path, contentType string, size int64, expires time.Duration) (*PresignedRequest, error)
+}
+
+// PresignedRequest is a request a client can make without credentials. All of
+// Headers must be sent with it.
+type PresignedRequest struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+	Metadata    map[string]string
 }
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// ReservationSweepJob releases stock reservations whose checkout expired, so
+// the held units become available to other customers again.
+type ReservationSweepJob struct {
+	cartService services.CartServiceInterface
+	logger      *zerolog.Logger
+}
+
+func NewReservationSweepJob(cartService services.CartServiceInterface, logger *zerolog.Logger) *ReservationSweepJob {
+	return &ReservationSweepJob{
+		cartService: cartService,
+		logger:      logger,
+	}
+}
+
+func (j *ReservationSweepJob) Name() string {
+	return "reservation-sweep"
+}
+
+func (j *ReservationSweepJob) Run(ctx context.Context) error {
+	released, err := j.cartService.ReleaseExpiredReservations(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if released > 0 {
+		j.logger.Info().Int("reservations", released).Msg("released expired stock reservations")
+	}
+
+	return nil
+}
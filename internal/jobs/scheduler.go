@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of background work that the Scheduler runs periodically
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type scheduledJob struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler runs each registered job on its own interval. A job is never run
+// concurrently with itself; a run that overlaps the next tick delays it.
+type Scheduler struct {
+	logger *zerolog.Logger
+	jobs   []scheduledJob
+}
+
+func NewScheduler(logger *zerolog.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Every registers job to run once at start and then every interval. A job
+// with an interval that is not positive is refused and never runs.
+func (s *Scheduler) Every(interval time.Duration, job Job) {
+	if interval <= 0 {
+		s.logger.Error().Str("job", job.Name()).Dur("interval", interval).Msg("job not scheduled: interval must be positive")
+		return
+	}
+
+	s.jobs = append(s.jobs, scheduledJob{job: job, interval: interval})
+}
+
+// Start runs the registered jobs until ctx is cancelled and waits for
+// in-flight runs to finish before returning.
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, scheduled := range s.jobs {
+		wg.Add(1)
+		go func(scheduled scheduledJob) {
+			defer wg.Done()
+			s.loop(ctx, scheduled)
+		}(scheduled)
+	}
+
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, scheduled scheduledJob) {
+	ticker := time.NewTicker(scheduled.interval)
+	defer ticker.Stop()
+
+	s.logger.Info().Str("job", scheduled.job.Name()).Dur("interval", scheduled.interval).Msg("job scheduled")
+
+	for {
+		s.run(ctx, scheduled.job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Error().Err(err).Str("job", job.Name()).Msg("job failed")
+		return
+	}
+
+	s.logger.Debug().Str("job", job.Name()).Dur("took", time.Since(start)).Msg("job finished")
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/joefazee/learning-go-shop/internal/services"
+)
+
+// SearchVocabularyJob refreshes the catalog words misspelled searches are
+// corrected to, so new and renamed products and categories are picked up.
+type SearchVocabularyJob struct {
+	productService services.ProductServiceInterface
+}
+
+func NewSearchVocabularyJob(productService services.ProductServiceInterface) *SearchVocabularyJob {
+	return &SearchVocabularyJob{
+		productService: productService,
+	}
+}
+
+func (j *SearchVocabularyJob) Name() string {
+	return "search-vocabulary"
+}
+
+func (j *SearchVocabularyJob) Run(ctx context.Context) error {
+	return j.productService.RefreshSearchVocabulary()
+}
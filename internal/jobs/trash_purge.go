@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// TrashPurgeJob permanently deletes catalog entities that have been in the
+// trash for longer than the retention period.
+type TrashPurgeJob struct {
+	trashService services.TrashServiceInterface
+	retention    time.Duration
+	logger       *zerolog.Logger
+}
+
+func NewTrashPurgeJob(trashService services.TrashServiceInterface, retention time.Duration, logger *zerolog.Logger) *TrashPurgeJob {
+	return &TrashPurgeJob{
+		trashService: trashService,
+		retention:    retention,
+		logger:       logger,
+	}
+}
+
+func (j *TrashPurgeJob) Name() string {
+	return "trash-purge"
+}
+
+func (j *TrashPurgeJob) Run(ctx context.Context) error {
+	summary, err := j.trashService.PurgeExpired(time.Now().Add(-j.retention))
+	if err != nil {
+		return err
+	}
+
+	if summary.Products > 0 || summary.Categories > 0 || summary.Skipped > 0 {
+		j.logger.Info().
+			Int("products", summary.Products).
+			Int("categories", summary.Categories).
+			Int("skipped", summary.Skipped).
+			Msg("purged expired trash")
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// UploadGCJob deletes stored uploads that nothing refers to any more, such as
+// the images of purged products, once they are older than the grace period.
+type UploadGCJob struct {
+	uploadService services.UploadServiceInterface
+	gracePeriod   time.Duration
+	logger        *zerolog.Logger
+}
+
+func NewUploadGCJob(uploadService services.UploadServiceInterface, gracePeriod time.Duration, logger *zerolog.Logger) *UploadGCJob {
+	return &UploadGCJob{
+		uploadService: uploadService,
+		gracePeriod:   gracePeriod,
+		logger:        logger,
+	}
+}
+
+func (j *UploadGCJob) Name() string {
+	return "upload-gc"
+}
+
+func (j *UploadGCJob) Run(ctx context.Context) error {
+	report, err := j.uploadService.CollectOrphanedUploads(time.Now().Add(-j.gracePeriod), false)
+	if err != nil {
+		return err
+	}
+
+	if report.Deleted > 0 || report.Failed > 0 {
+		j.logger.Info().
+			Int("scanned", report.Scanned).
+			Int("deleted", report.Deleted).
+			Int("failed", report.Failed).
+			Int64("bytes_reclaimed", report.BytesReclaimed).
+			Msg("deleted orphaned uploads")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/money"
+)
+
+// ExchangeRate converts prices from the store's base currency. Rate is the
+// number of units of Currency worth one unit of the base currency.
+type ExchangeRate struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Currency  string    `json:"currency" gorm:"uniqueIndex;not null"`
+	Rate      float64   `json:"rate" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductPrice is a price list entry that replaces the converted base price
+// of a product in one currency.
+type ProductPrice struct {
+	ID        uint        `json:"id" gorm:"primaryKey"`
+	ProductID uint        `json:"product_id" gorm:"not null"`
+	Currency  string      `json:"currency" gorm:"not null"`
+	Price     money.Money `json:"price" gorm:"not null"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// DownloadGrant entitles the buyer of a digital product to download one of
+// its files a limited number of times.
+type DownloadGrant struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	OrderID       uint      `json:"order_id" gorm:"not null"`
+	UserID        uint      `json:"user_id" gorm:"not null"`
+	ProductFileID uint      `json:"product_file_id" gorm:"not null"`
+	DownloadCount int       `json:"download_count" gorm:"not null;default:0"`
+	MaxDownloads  int       `json:"max_downloads" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	// Relationships
+	Order       Order       `json:"-"`
+	ProductFile ProductFile `json:"product_file"`
+}
@@ -3,17 +3,21 @@ package models
 import (
 	"time"
 
+	"github.com/joefazee/learning-go-shop/internal/money"
 	"gorm.io/gorm"
 )
 
 type Order struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	Status      OrderStatus    `json:"status" gorm:"default:pending"`
-	TotalAmount float64        `json:"total_amount" gorm:"not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	UserID           uint           `json:"user_id" gorm:"not null"`
+	Status           OrderStatus    `json:"status" gorm:"default:pending"`
+	TotalAmount      money.Money    `json:"total_amount" gorm:"not null"`
+	Currency         string         `json:"currency" gorm:"not null"`
+	ExchangeRate     float64        `json:"exchange_rate" gorm:"not null"`
+	RequiresShipping bool           `json:"requires_shipping" gorm:"not null"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	User       User        `json:"user"`
@@ -35,13 +39,34 @@ type OrderItem struct {
 	OrderID   uint           `json:"order_id" gorm:"not null"`
 	ProductID uint           `json:"product_id" gorm:"not null"`
 	Quantity  int            `json:"quantity" gorm:"not null"`
-	Price     float64        `json:"price" gorm:"not null"`
+	Price     money.Money    `json:"price" gorm:"not null"`
 	CreatedAt time.Time      `json:"created_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Backordered is the units of the line still waiting for stock, expected
+	// to ship on ExpectedShipDate when the product gave one
+	Backordered      int        `json:"backordered" gorm:"default:0"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date"`
+
 	// Relationships
-	Order   Order   `json:"-"`
-	Product Product `json:"product"`
+	Order       Order                 `json:"-"`
+	Product     Product               `json:"product"`
+	Components  []OrderItemComponent  `json:"components"`
+	Allocations []OrderItemAllocation `json:"allocations"`
+}
+
+// OrderItemComponent records the units of a component product shipped for a
+// bundle order line. Quantity is the total for the line, not per bundle.
+type OrderItemComponent struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	OrderItemID uint      `json:"order_item_id" gorm:"not null"`
+	ProductID   uint      `json:"product_id" gorm:"not null"`
+	Quantity    int       `json:"quantity" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Relationships
+	OrderItem OrderItem `json:"-"`
+	Product   Product   `json:"product"`
 }
 
 type Cart struct {
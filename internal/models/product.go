@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/joefazee/learning-go-shop/internal/money"
 	"gorm.io/gorm"
 )
 
@@ -16,7 +17,8 @@ type Category struct {
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	Products []Product `json:"-"`
+	Products     []Product             `json:"-"`
+	Translations []CategoryTranslation `json:"-"`
 }
 
 type Product struct {
@@ -24,19 +26,79 @@ type Product struct {
 	CategoryID  uint           `json:"category_id" gorm:"not null"`
 	Name        string         `json:"name" gorm:"not null"`
 	Description string         `json:"description"`
-	Price       float64        `json:"price" gorm:"not null"`
+	Price       money.Money    `json:"price" gorm:"not null"`
 	Stock       int            `json:"stock" gorm:"default:0"`
+	Reserved    int            `json:"reserved" gorm:"default:0"`
 	SKU         string         `json:"sku" gorm:"uniqueIndex;not null"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	IsDigital   bool           `json:"is_digital" gorm:"default:false"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Aggregates over approved reviews, maintained on moderation
+	RatingAverage float64 `json:"rating_average" gorm:"default:0"`
+	RatingCount   int     `json:"rating_count" gorm:"default:0"`
+
+	// LowStockThreshold overrides the store-wide low-stock threshold when set
+	LowStockThreshold *int `json:"low_stock_threshold"`
+
+	// Selling beyond stock. Backordered is the units sold but not yet taken
+	// from stock, at most BackorderLimit unless that is 0. Bundles and digital
+	// products always use StockPolicyDeny.
+	StockPolicy      StockPolicy `json:"stock_policy" gorm:"default:deny"`
+	BackorderLimit   int         `json:"backorder_limit" gorm:"default:0"`
+	Backordered      int         `json:"backordered" gorm:"default:0"`
+	ExpectedShipDate *time.Time  `json:"expected_ship_date"`
+
+	// Bundle settings, only meaningful when IsBundle is set
+	IsBundle         bool          `json:"is_bundle" gorm:"default:false"`
+	BundlePricing    BundlePricing `json:"bundle_pricing" gorm:"default:fixed"`
+	BundlePercentage float64       `json:"bundle_percentage" gorm:"default:100"`
+
 	// Relationships
-	Category   Category       `json:"category"`
-	Images     []ProductImage `json:"images"`
-	OrderItems []OrderItem    `json:"-"`
-	CartItems  []CartItem     `json:"-"`
+	Category    Category       `json:"category"`
+	Images      []ProductImage `json:"images"`
+	BundleItems []BundleItem   `json:"bundle_items" gorm:"foreignKey:BundleID"`
+	Files       []ProductFile  `json:"-"`
+	Reviews     []Review       `json:"-"`
+	OrderItems  []OrderItem    `json:"-"`
+	CartItems   []CartItem     `json:"-"`
+
+	Translations []ProductTranslation `json:"-"`
+}
+
+type BundlePricing string
+
+const (
+	// BundlePricingFixed sells the bundle at its own Price
+	BundlePricingFixed BundlePricing = "fixed"
+	// BundlePricingPercentage sells the bundle at BundlePercentage of the component total
+	BundlePricingPercentage BundlePricing = "percentage"
+)
+
+type StockPolicy string
+
+const (
+	// StockPolicyDeny only sells what is in stock
+	StockPolicyDeny StockPolicy = "deny"
+	// StockPolicyBackorder sells from stock, then backorders the rest
+	StockPolicyBackorder StockPolicy = "backorder"
+	// StockPolicyPreorder backorders every unit until the expected ship date
+	StockPolicyPreorder StockPolicy = "preorder"
+)
+
+type BundleItem struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	BundleID    uint      `json:"bundle_id" gorm:"not null"`
+	ComponentID uint      `json:"component_id" gorm:"not null"`
+	Quantity    int       `json:"quantity" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Bundle    Product `json:"-"`
+	Component Product `json:"component"`
 }
 
 type ProductImage struct {
@@ -44,10 +106,53 @@ type ProductImage struct {
 	ProductID uint           `json:"product_id" gorm:"not null"`
 	URL       string         `json:"url" gorm:"not null"`
 	AltText   string         `json:"alt_text"`
-	IsPrimary bool           `json:"is_primary" gorm:"default:false"`
+	IsPrimary bool           `json:"is_primary" gorm:"not null;default:false"`
+	Position  int            `json:"position" gorm:"not null;default:0"`
 	CreatedAt time.Time      `json:"created_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// ContentHash is the SHA-256 of the uploaded file, which names its stored
+	// files. Images uploaded before hashing have none and own their files.
+	ContentHash *string `json:"content_hash"`
+
+	// Relationships
+	Product    Product                 `json:"-"`
+	Renditions []ProductImageRendition `json:"renditions"`
+}
+
+// ImageObject counts the product images sharing the files stored for an
+// uploaded file's content
+type ImageObject struct {
+	ContentHash string    `json:"content_hash" gorm:"primaryKey"`
+	RefCount    int       `json:"ref_count" gorm:"not null;default:0"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ProductImageRendition is a resized version of a product image, in the
+// image's own format or WebP
+type ProductImageRendition struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	ProductImageID uint      `json:"product_image_id" gorm:"not null"`
+	Name           string    `json:"name" gorm:"not null"`
+	Format         string    `json:"format" gorm:"not null"`
+	Path           string    `json:"path" gorm:"not null"`
+	Width          int       `json:"width" gorm:"not null"`
+	Height         int       `json:"height" gorm:"not null"`
+	Size           int64     `json:"size" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ProductFile is a privately stored file delivered to buyers of a digital product
+type ProductFile struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	ProductID   uint           `json:"product_id" gorm:"not null"`
+	StorageKey  string         `json:"-" gorm:"not null"`
+	FileName    string         `json:"file_name" gorm:"not null"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
 	// Relationships
 	Product Product `json:"-"`
 }
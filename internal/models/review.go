@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Review struct {
+	ID                 uint           `json:"id" gorm:"primaryKey"`
+	ProductID          uint           `json:"product_id" gorm:"not null"`
+	UserID             uint           `json:"user_id" gorm:"not null"`
+	Rating             int            `json:"rating" gorm:"not null"`
+	Title              string         `json:"title"`
+	Body               string         `json:"body" gorm:"not null"`
+	IsVerifiedPurchase bool           `json:"is_verified_purchase" gorm:"not null"`
+	Status             ReviewStatus   `json:"status" gorm:"default:pending"`
+	HelpfulCount       int            `json:"helpful_count" gorm:"not null;default:0"`
+	ModeratedAt        *time.Time     `json:"moderated_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Product Product `json:"-"`
+	User    User    `json:"user"`
+}
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// ReviewVote records that a user found a review helpful
+type ReviewVote struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ReviewID  uint      `json:"review_id" gorm:"not null"`
+	UserID    uint      `json:"user_id" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
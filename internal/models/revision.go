@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate   RevisionAction = "create"
+	RevisionActionUpdate   RevisionAction = "update"
+	RevisionActionDelete   RevisionAction = "delete"
+	RevisionActionRestore  RevisionAction = "restore"
+	RevisionActionRollback RevisionAction = "rollback"
+)
+
+// Revision is an immutable record of a change to a catalog entity. Changes
+// holds the field-level diff and Snapshot the tracked fields after the change.
+type Revision struct {
+	ID           uint            `json:"id" gorm:"primaryKey"`
+	EntityType   string          `json:"entity_type" gorm:"not null"`
+	EntityID     uint            `json:"entity_id" gorm:"not null"`
+	Version      int             `json:"version" gorm:"not null"`
+	Action       RevisionAction  `json:"action" gorm:"not null"`
+	ActorID      *uint           `json:"actor_id"`
+	RollbackOfID *uint           `json:"rollback_of_id"`
+	Changes      json.RawMessage `json:"changes" gorm:"type:jsonb"`
+	Snapshot     json.RawMessage `json:"snapshot" gorm:"type:jsonb"`
+	CreatedAt    time.Time       `json:"created_at"`
+
+	// Relationships
+	Actor *User `json:"actor,omitempty"`
+}
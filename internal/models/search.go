@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// SearchQuery counts the searches for a normalized query in a locale that
+// found products. Popular queries are offered as search suggestions.
+type SearchQuery struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Locale         string    `json:"locale" gorm:"not null"`
+	Query          string    `json:"query" gorm:"not null"`
+	SearchCount    int       `json:"search_count" gorm:"not null;default:1"`
+	ResultCount    int       `json:"result_count" gorm:"not null;default:0"`
+	LastSearchedAt time.Time `json:"last_searched_at"`
+}
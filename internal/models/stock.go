@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// StockReservation holds units of a product for a user's checkout until it
+// expires. The units are counted in the product's Reserved column.
+type StockReservation struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null"`
+	ProductID uint      `json:"product_id" gorm:"not null"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	User    User    `json:"-"`
+	Product Product `json:"-"`
+}
+
+// StockSubscription asks for a user to be emailed when an out of stock
+// product is back in stock. It is removed once the user has been notified.
+type StockSubscription struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null"`
+	ProductID uint      `json:"product_id" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	User    User    `json:"-"`
+	Product Product `json:"-"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type StockMovementReason string
+
+const (
+	StockMovementSale       StockMovementReason = "sale"
+	StockMovementRestock    StockMovementReason = "restock"
+	StockMovementAdjustment StockMovementReason = "adjustment"
+	StockMovementReturn     StockMovementReason = "return"
+	StockMovementDamage     StockMovementReason = "damage"
+	StockMovementTransfer   StockMovementReason = "transfer"
+)
+
+// StockMovement is an entry in the append-only stock ledger. The sum of a
+// product's movements at a warehouse is its stock there.
+type StockMovement struct {
+	ID          uint                `json:"id" gorm:"primaryKey"`
+	ProductID   uint                `json:"product_id" gorm:"not null"`
+	WarehouseID uint                `json:"warehouse_id" gorm:"not null"`
+	Quantity    int                 `json:"quantity" gorm:"not null"`
+	Reason      StockMovementReason `json:"reason" gorm:"not null"`
+	ActorID     *uint               `json:"actor_id"`
+	Reference   string              `json:"reference" gorm:"not null;default:''"`
+	CreatedAt   time.Time           `json:"created_at"`
+
+	// Relationships
+	Product   Product   `json:"-"`
+	Warehouse Warehouse `json:"warehouse"`
+	Actor     *User     `json:"actor,omitempty"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// DefaultLocale is the language of the content stored on products and
+// categories themselves. Other locales are translations that fall back to it.
+const DefaultLocale = "en"
+
+type ProductTranslation struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ProductID   uint      `json:"product_id" gorm:"not null"`
+	Locale      string    `json:"locale" gorm:"not null"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CategoryTranslation struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CategoryID  uint      `json:"category_id" gorm:"not null"`
+	Locale      string    `json:"locale" gorm:"not null"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PendingUpload is a product image a client was given a presigned URL to
+// upload directly to storage. It is removed once the upload is confirmed.
+type PendingUpload struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ProductID   uint      `json:"product_id" gorm:"not null"`
+	Key         string    `json:"key" gorm:"not null;uniqueIndex"`
+	Filename    string    `json:"filename" gorm:"not null"`
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
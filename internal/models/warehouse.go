@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type Warehouse struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Code      string    `json:"code" gorm:"uniqueIndex;not null"`
+	Name      string    `json:"name" gorm:"not null"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	Priority  int       `json:"priority" gorm:"not null;default:0"`
+	IsDefault bool      `json:"is_default" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseStock is the on-hand quantity of a product at a warehouse. The
+// product's Stock is the total over all warehouses.
+type WarehouseStock struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	WarehouseID uint      `json:"warehouse_id" gorm:"not null"`
+	ProductID   uint      `json:"product_id" gorm:"not null"`
+	Quantity    int       `json:"quantity" gorm:"not null;default:0"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Warehouse Warehouse `json:"warehouse"`
+	Product   Product   `json:"-"`
+}
+
+// OrderItemAllocation records the units of a product an order line is
+// shipped from a warehouse. For bundles the product is the component.
+type OrderItemAllocation struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	OrderItemID uint      `json:"order_item_id" gorm:"not null"`
+	WarehouseID uint      `json:"warehouse_id" gorm:"not null"`
+	ProductID   uint      `json:"product_id" gorm:"not null"`
+	Quantity    int       `json:"quantity" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Relationships
+	OrderItem OrderItem `json:"-"`
+	Warehouse Warehouse `json:"warehouse"`
+}
@@ -0,0 +1,221 @@
+// Package money represents amounts of money exactly, as integer minor units.
+//
+// Every currency the store sells in is priced to two decimal places. Amounts
+// are parsed from their decimal text, never through a float. Exchange rates
+// and percentages are applied exactly to their decimal value, and the result
+// is rounded once to the nearest minor unit, halves away from zero.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor units (hundredths) of its currency
+type Money int64
+
+const (
+	scale  = 100
+	places = 2
+)
+
+var ErrInvalidAmount = errors.New("amount must be a decimal number with at most two decimal places")
+
+// Parse reads a decimal amount such as "12", "12.5" or "-0.99". More than two
+// decimal places is an error rather than a silent rounding.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalidAmount
+	}
+
+	if whole == "" {
+		whole = "0"
+	}
+
+	if hasFraction && fraction == "" || len(fraction) > places {
+		return 0, ErrInvalidAmount
+	}
+
+	for _, part := range []string{whole, fraction} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return 0, ErrInvalidAmount
+			}
+		}
+	}
+
+	var cents int64
+	if fraction != "" {
+		fraction += strings.Repeat("0", places-len(fraction))
+		cents, _ = strconv.ParseInt(fraction, 10, 64)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > (math.MaxInt64-cents)/scale {
+		return 0, ErrInvalidAmount
+	}
+
+	amount := Money(units*scale + cents)
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// Times multiplies the amount by a quantity, exactly
+func (m Money) Times(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Convert multiplies the amount by an exchange rate, exactly, and rounds the
+// result to the nearest minor unit with halves rounded away from zero
+func (m Money) Convert(rate float64) Money {
+	return round(new(big.Rat).Mul(m.rat(), decimal(rate)))
+}
+
+// ConvertBack divides the amount by an exchange rate, undoing Convert, and
+// rounds like Convert does
+func (m Money) ConvertBack(rate float64) Money {
+	divisor := decimal(rate)
+	if divisor.Sign() == 0 {
+		return 0
+	}
+
+	return round(new(big.Rat).Quo(m.rat(), divisor))
+}
+
+// Percent returns percent of the amount, rounded like Convert
+func (m Money) Percent(percent float64) Money {
+	share := new(big.Rat).Mul(m.rat(), decimal(percent))
+	return round(share.Quo(share, big.NewRat(100, 1)))
+}
+
+func (m Money) rat() *big.Rat {
+	return new(big.Rat).SetInt64(int64(m))
+}
+
+// decimal returns f as the shortest decimal that reads back as f. Rates and
+// percentages are stored as decimals, so this is the stored value rather
+// than the float nearest it. NaN and infinities are taken as 0.
+func decimal(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+
+	return r
+}
+
+// round rounds r to a whole number of minor units, halves away from zero, so
+// 0.125 becomes 0.13 and -0.125 becomes -0.13. Results beyond the range of
+// Money are clamped to it.
+func round(r *big.Rat) Money {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(r.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(r.Sign())))
+	}
+
+	switch {
+	case quotient.IsInt64():
+		return Money(quotient.Int64())
+	case quotient.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/scale, value%scale)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam binds the amount from a query or form parameter
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := Parse(param)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// MarshalGQL writes the amount as a GraphQL Money scalar
+func (m Money) MarshalGQL(w io.Writer) {
+	_, _ = io.WriteString(w, m.String())
+}
+
+// UnmarshalGQL reads a Money scalar given as a number or a string
+func (m *Money) UnmarshalGQL(v interface{}) error {
+	var text string
+	switch value := v.(type) {
+	case string:
+		text = value
+	case json.Number:
+		text = value.String()
+	case int:
+		text = strconv.Itoa(value)
+	case int64:
+		text = strconv.FormatInt(value, 10)
+	case float64:
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Errorf("money must be a number, got %T", v)
+	}
+
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
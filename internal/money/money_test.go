@@ -0,0 +1,256 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: "12", want: 1200},
+		{in: "12.5", want: 1250},
+		{in: "12.50", want: 1250},
+		{in: " 3.10 ", want: 310},
+		{in: ".5", want: 50},
+		{in: "+1.00", want: 100},
+		{in: "-0.99", want: -99},
+		{in: "-12", want: -1200},
+		{in: "0", want: 0},
+		{in: "92233720368547758.07", want: math.MaxInt64},
+		{in: "-92233720368547758.07", want: -math.MaxInt64},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "1.", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "0.001", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "12a", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+		{in: "92233720368547759", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("Parse(%q) = %v, %v, want ErrInvalidAmount", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 5, want: "0.05"},
+		{in: 1250, want: "12.50"},
+		{in: -99, want: "-0.99"},
+		{in: -1200, want: "-12.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestTimes(t *testing.T) {
+	tests := []struct {
+		m        Money
+		quantity int
+		want     Money
+	}{
+		{m: 199, quantity: 3, want: 597},
+		{m: 199, quantity: 0, want: 0},
+		{m: 199, quantity: -2, want: -398},
+		{m: -250, quantity: 4, want: -1000},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.Times(tt.quantity); got != tt.want {
+			t.Errorf("Money(%v).Times(%d) = %v, want %v", tt.m, tt.quantity, got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		rate float64
+		want Money
+	}{
+		{name: "exact", m: 1000, rate: 1.5, want: 1500},
+		{name: "identity", m: 1234, rate: 1, want: 1234},
+		{name: "rounds down below a half", m: 100, rate: 0.1234, want: 12},
+		{name: "rounds up above a half", m: 100, rate: 0.1267, want: 13},
+		{name: "half rounds away from zero", m: 1, rate: 0.5, want: 1},
+		{name: "negative half rounds away from zero", m: -1, rate: 0.5, want: -1},
+		{name: "half the float product misses", m: 50, rate: 1.15, want: 58},
+		{name: "negative half the float product misses", m: -50, rate: 1.15, want: -58},
+		{name: "rate with eight decimal places", m: 100000, rate: 0.00012345, want: 12},
+		{name: "large amount", m: 987654321098765, rate: 1.0725, want: 1059259259378425},
+		{name: "clamped", m: math.MaxInt64, rate: 2, want: math.MaxInt64},
+		{name: "not a number", m: 100, rate: math.NaN(), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Convert(tt.rate); got != tt.want {
+				t.Errorf("Money(%v).Convert(%v) = %v, want %v", tt.m, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertBack(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		rate float64
+		want Money
+	}{
+		{name: "exact", m: 1500, rate: 1.5, want: 1000},
+		{name: "undoes Convert", m: 58, rate: 1.15, want: 50},
+		{name: "rounds down below a half", m: 100, rate: 3, want: 33},
+		{name: "rounds up above a half", m: 200, rate: 3, want: 67},
+		{name: "half rounds away from zero", m: 1, rate: 2, want: 1},
+		{name: "negative half rounds away from zero", m: -1, rate: 2, want: -1},
+		{name: "zero rate", m: 100, rate: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.ConvertBack(tt.rate); got != tt.want {
+				t.Errorf("Money(%v).ConvertBack(%v) = %v, want %v", tt.m, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Money
+		percent float64
+		want    Money
+	}{
+		{name: "whole", m: 10000, percent: 100, want: 10000},
+		{name: "exact", m: 10000, percent: 85, want: 8500},
+		{name: "fractional percent", m: 10000, percent: 12.5, want: 1250},
+		{name: "rounds down below a half", m: 1001, percent: 10, want: 100},
+		{name: "rounds up above a half", m: 1006, percent: 10, want: 101},
+		{name: "half rounds away from zero", m: 115, percent: 50, want: 58},
+		{name: "negative half rounds away from zero", m: -115, percent: 50, want: -58},
+		{name: "large amount", m: 987654321098765, percent: 107.25, want: 1059259259378425},
+		{name: "zero", m: 10000, percent: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Percent(tt.percent); got != tt.want {
+				t.Errorf("Money(%v).Percent(%v) = %v, want %v", tt.m, tt.percent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type line struct {
+		Price Money `json:"price"`
+	}
+
+	tests := []struct {
+		in      string
+		want    Money
+		out     string
+		wantErr bool
+	}{
+		{in: `{"price":12.5}`, want: 1250, out: `{"price":12.50}`},
+		{in: `{"price":"12.50"}`, want: 1250, out: `{"price":12.50}`},
+		{in: `{"price":-0.99}`, want: -99, out: `{"price":-0.99}`},
+		{in: `{"price":0}`, want: 0, out: `{"price":0.00}`},
+		{in: `{"price":null}`, want: 0, out: `{"price":0.00}`},
+		{in: `{"price":1.234}`, wantErr: true},
+		{in: `{"price":"abc"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got line
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("json.Unmarshal(%s) = %v, want an error", tt.in, got.Price)
+			}
+			continue
+		}
+		if err != nil || got.Price != tt.want {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", tt.in, got.Price, err, tt.want)
+			continue
+		}
+
+		out, err := json.Marshal(got)
+		if err != nil || string(out) != tt.out {
+			t.Errorf("json.Marshal(%v) = %s, %v, want %s", got.Price, out, err, tt.out)
+		}
+	}
+}
+
+func TestGQL(t *testing.T) {
+	tests := []struct {
+		in      interface{}
+		want    Money
+		out     string
+		wantErr bool
+	}{
+		{in: "12.50", want: 1250, out: "12.50"},
+		{in: json.Number("-0.99"), want: -99, out: "-0.99"},
+		{in: 12, want: 1200, out: "12.00"},
+		{in: int64(7), want: 700, out: "7.00"},
+		{in: 12.5, want: 1250, out: "12.50"},
+		{in: 0.1, want: 10, out: "0.10"},
+		{in: 1.234, wantErr: true},
+		{in: "1.", wantErr: true},
+		{in: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := got.UnmarshalGQL(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("UnmarshalGQL(%#v) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("UnmarshalGQL(%#v) = %v, %v, want %v", tt.in, got, err, tt.want)
+			continue
+		}
+
+		var out bytes.Buffer
+		got.MarshalGQL(&out)
+		if out.String() != tt.out {
+			t.Errorf("MarshalGQL(%v) = %s, want %s", got, out.String(), tt.out)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package notifications
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"net/smtp"
+	"strconv"
+	"strings"
 )
 
 type SMTPConfig struct {
@@ -31,7 +34,7 @@ func NewEmailNotifier(config *SMTPConfig) *EmailNotifier {
 }
 
 func (e *EmailNotifier) SendSimpleEmail(email *SimpleEmail) error {
-	addr := fmt.Sprintf("%s:%d", e.config.Host, e.config.Port)
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
 
 	// Connect directly without TLS for development
 	conn, err := net.Dial("tcp", addr)
@@ -98,3 +101,100 @@ The Shop Team`, userName),
 
 	return e.SendSimpleEmail(email)
 }
+
+func (e *EmailNotifier) SendDownloadLinks(userEmail, userName string, orderID uint, links []DownloadLink) error {
+	var list strings.Builder
+	for _, link := range links {
+		fmt.Fprintf(&list, "- %s (%s): %s\n  Link expires %s\n",
+			link.ProductName, link.FileName, link.URL, link.ExpiresAt.Format("Jan 2, 2006 15:04 MST"))
+	}
+
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: fmt.Sprintf("Your downloads for order #%d", orderID),
+		Body: fmt.Sprintf(`Hello %s,
+
+Thank you for your order. Your digital products are ready to download:
+
+%s
+Each link expires and can only be used a limited number of times. You can get
+fresh links at any time from your order history.
+
+Best regards,
+The Shop Team`, userName, list.String()),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+func (e *EmailNotifier) SendReviewReceived(userEmail, userName, productName string) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: fmt.Sprintf("Thanks for reviewing %s", productName),
+		Body: fmt.Sprintf(`Hello %s,
+
+Thank you for reviewing %s. Your review has been received and will appear on
+the product page once it has been approved by our team.
+
+Best regards,
+The Shop Team`, userName, productName),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+func (e *EmailNotifier) SendBackInStock(userEmail, userName, productName string) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: fmt.Sprintf("%s is back in stock", productName),
+		Body: fmt.Sprintf(`Hello %s,
+
+Good news: %s is back in stock. Stock may be limited, so order soon if you
+still want it.
+
+You asked to be told when it was available again. We won't email you about it
+again unless you subscribe once more.
+
+Best regards,
+The Shop Team`, userName, productName),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+// SendLowStockAlerts emails the staff list about products that are low on
+// stock, one email for all of the alerts
+func (e *EmailNotifier) SendLowStockAlerts(recipients []string, alerts []LowStockPayload) error {
+	var list strings.Builder
+	for _, alert := range alerts {
+		fmt.Fprintf(&list, "- %s (SKU %s): %d in stock, threshold %d, at %s\n",
+			alert.ProductName, alert.SKU, alert.Stock, alert.Threshold, alert.OccurredAt.Format("Jan 2, 2006 15:04 MST"))
+	}
+
+	subject := fmt.Sprintf("Low stock: %s", alerts[0].ProductName)
+	if len(alerts) > 1 {
+		subject = fmt.Sprintf("Low stock: %d products", len(alerts))
+	}
+
+	var errs []error
+	for _, recipient := range recipients {
+		email := &SimpleEmail{
+			To:      recipient,
+			Subject: subject,
+			Body: fmt.Sprintf(`Hello,
+
+The following products are running low on stock:
+
+%s
+Restock them or adjust their thresholds in the admin.
+
+The Shop`, list.String()),
+		}
+
+		if err := e.SendSimpleEmail(email); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", recipient, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
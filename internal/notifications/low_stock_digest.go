@@ -0,0 +1,42 @@
+package notifications
+
+import "sort"
+
+// LowStockDigest collects low-stock alerts between digest emails, keeping the
+// latest alert for each product. It is not safe for concurrent use.
+type LowStockDigest struct {
+	alerts map[uint]LowStockPayload
+}
+
+func NewLowStockDigest() *LowStockDigest {
+	return &LowStockDigest{alerts: make(map[uint]LowStockPayload)}
+}
+
+func (d *LowStockDigest) Add(alert LowStockPayload) {
+	d.alerts[alert.ProductID] = alert
+}
+
+// Take returns the collected alerts ordered by product name and empties the digest
+func (d *LowStockDigest) Take() []LowStockPayload {
+	alerts := make([]LowStockPayload, 0, len(d.alerts))
+	for _, alert := range d.alerts {
+		alerts = append(alerts, alert)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].ProductName < alerts[j].ProductName
+	})
+
+	d.alerts = make(map[uint]LowStockPayload)
+	return alerts
+}
+
+// Restore puts back alerts taken for a digest that could not be sent. Alerts
+// added since for the same product are newer, so they are kept instead.
+func (d *LowStockDigest) Restore(alerts []LowStockPayload) {
+	for _, alert := range alerts {
+		if _, ok := d.alerts[alert.ProductID]; !ok {
+			d.alerts[alert.ProductID] = alert
+		}
+	}
+}
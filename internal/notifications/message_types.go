@@ -1,5 +1,9 @@
 package notifications
 
 const (
-	UserLoggedIn = "USER_LOGGED_IN"
+	UserLoggedIn        = "USER_LOGGED_IN"
+	OrderDownloadsReady = "ORDER_DOWNLOADS_READY"
+	ReviewSubmitted     = "REVIEW_SUBMITTED"
+	ProductLowStock     = "PRODUCT_LOW_STOCK"
+	ProductBackInStock  = "PRODUCT_BACK_IN_STOCK"
 )
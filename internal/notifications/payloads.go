@@ -0,0 +1,57 @@
+package notifications
+
+import "time"
+
+// DownloadLink is a single expiring link in a DownloadsReadyPayload
+type DownloadLink struct {
+	ProductName string    `json:"product_name"`
+	FileName    string    `json:"file_name"`
+	URL         string    `json:"url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// DownloadsReadyPayload is published with OrderDownloadsReady events
+type DownloadsReadyPayload struct {
+	OrderID   uint           `json:"order_id"`
+	Email     string         `json:"email"`
+	FirstName string         `json:"first_name"`
+	LastName  string         `json:"last_name"`
+	Links     []DownloadLink `json:"links"`
+}
+
+// LowStockPayload is published with ProductLowStock events when a product's
+// on-hand stock falls to or below its threshold
+type LowStockPayload struct {
+	ProductID   uint      `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	SKU         string    `json:"sku"`
+	Stock       int       `json:"stock"`
+	Threshold   int       `json:"threshold"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// Subscriber is a customer waiting for a product to be back in stock
+type Subscriber struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// BackInStockPayload is published with ProductBackInStock events, one event
+// per batch of subscribers
+type BackInStockPayload struct {
+	ProductID   uint         `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	Subscribers []Subscriber `json:"subscribers"`
+}
+
+// ReviewSubmittedPayload is published with ReviewSubmitted events
+type ReviewSubmittedPayload struct {
+	ReviewID    uint   `json:"review_id"`
+	ProductID   uint   `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Rating      int    `json:"rating"`
+	Email       string `json:"email"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+}
@@ -1,51 +1,135 @@
 package providers
 
 import (
-	"mime/multipart"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
 )
 
 type LocalUploadProvider struct {
 	basePath string
+	// baseURL is where basePath is served from, empty if it is not served
+	baseURL string
 }
 
-func NewLocalUploadProvider(basePath string) *LocalUploadProvider {
-	return &LocalUploadProvider{basePath: basePath}
+func NewLocalUploadProvider(basePath, baseURL string) *LocalUploadProvider {
+	return &LocalUploadProvider{basePath: basePath, baseURL: strings.TrimSuffix(baseURL, "/")}
 }
 
-func (p *LocalUploadProvider) UploadFile(file *multipart.FileHeader, path string) (string, error) {
+var _ interfaces.UploadProvider = (*LocalUploadProvider)(nil)
 
+// Put writes to a temporary file that replaces path once complete, so a failed
+// or short write never leaves a partial file behind. Content type, cache
+// control and metadata are not kept.
+func (p *LocalUploadProvider) Put(path string, r io.Reader, opts interfaces.PutOptions) (string, error) {
 	fullPath := filepath.Join(p.basePath, path)
 
 	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
 		return "", err
 	}
 
-	// Open source
-	src, err := file.Open()
+	dst, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
 	if err != nil {
 		return "", err
 	}
-	defer src.Close()
+	defer os.Remove(dst.Name())
 
-	// create destination
-	dst, err := os.Create(fullPath)
+	written, err := io.Copy(dst, r)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return "", err
 	}
-	defer dst.Close()
+	if opts.Size >= 0 && written != opts.Size {
+		return "", fmt.Errorf("%w: read %d bytes, expected %d", interfaces.ErrSizeMismatch, written, opts.Size)
+	}
 
-	// read from source to destination
-	if _, err := dst.ReadFrom(src); err != nil {
+	if err := os.Chmod(dst.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(dst.Name(), fullPath); err != nil {
 		return "", err
 	}
 
 	return path, nil
+}
+
+func (p *LocalUploadProvider) Open(path string) (io.ReadCloser, error) {
+	fullPath := filepath.Join(p.basePath, path)
+	return os.Open(fullPath)
+}
+
+func (p *LocalUploadProvider) Stat(path string) (*interfaces.ObjectInfo, error) {
+	info, err := os.Stat(filepath.Join(p.basePath, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, interfaces.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 
+	return &interfaces.ObjectInfo{
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+		ModifiedAt:  info.ModTime(),
+	}, nil
 }
 
 func (p *LocalUploadProvider) DeleteFile(path string) error {
 	fullPath := filepath.Join(p.basePath, path)
 	return os.Remove(fullPath)
 }
+
+func (p *LocalUploadProvider) URL(path string) (string, error) {
+	if isAbsoluteURL(path) {
+		return path, nil
+	}
+	if p.baseURL == "" {
+		return "", interfaces.ErrNoPublicURL
+	}
+
+	return p.baseURL + "/" + strings.TrimPrefix(path, "/"), nil
+}
+
+func (p *LocalUploadProvider) List(prefix string) ([]interfaces.StoredObject, error) {
+	var objects []interfaces.StoredObject
+
+	err := filepath.WalkDir(filepath.Join(p.basePath, prefix), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		key, err := filepath.Rel(p.basePath, path)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, interfaces.StoredObject{
+			Key:        filepath.ToSlash(key),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
+		})
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return objects, err
+}
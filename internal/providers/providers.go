@@ -0,0 +1,37 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
+)
+
+// NewUploadProviders returns the configured providers for public files, such
+// as product images, and for private files that are never served directly
+func NewUploadProviders(cfg *config.Config) (public, private interfaces.UploadProvider) {
+	if cfg.Upload.UploadProvider == "s3" {
+		public = NewS3Provider(cfg, cfg.AWS.S3Bucket, S3URLOptions{
+			BaseURL: cfg.Upload.CDNBaseURL,
+			Signed:  cfg.Upload.SignURLs,
+			TTL:     cfg.Upload.SignedURLTTL,
+		})
+		private = NewS3Provider(cfg, cfg.AWS.S3PrivateBucket, S3URLOptions{Private: true})
+		return public, private
+	}
+
+	baseURL := cfg.Upload.CDNBaseURL
+	if baseURL == "" {
+		baseURL = strings.TrimSuffix(cfg.Server.PublicURL, "/") + "/uploads"
+	}
+
+	public = NewLocalUploadProvider(cfg.Upload.Path, baseURL)
+	private = NewLocalUploadProvider(cfg.Upload.PrivatePath, "")
+	return public, private
+}
+
+// isAbsoluteURL reports whether a stored path is already a full URL, as the
+// URLs of images uploaded before keys were resolved can be
+func isAbsoluteURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
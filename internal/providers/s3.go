@@ -2,26 +2,55 @@ package providers
 
 import (
 	"context"
-	"mime/multipart"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 
 	appconfig "github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
 )
 
+var (
+	_ interfaces.UploadProvider       = (*S3Provider)(nil)
+	_ interfaces.DirectUploadProvider = (*S3Provider)(nil)
+)
+
+// S3URLOptions decide the URLs a bucket's objects are served from. Signed URLs
+// take precedence, then BaseURL, usually a CDN, and otherwise the bucket's own
+// S3 URL is used.
+type S3URLOptions struct {
+	BaseURL string
+	// Signed serves objects from a private bucket with URLs valid for TTL
+	Signed bool
+	TTL    time.Duration
+	// Private buckets are never served directly
+	Private bool
+}
+
 type S3Provider struct {
-	client   *s3.Client
-	uploader *manager.Uploader
-	bucket   string
-	endpoint string
+	client    *s3.Client
+	uploader  *manager.Uploader
+	presigner *s3.PresignClient
+	bucket    string
+	endpoint  string
+	region    string
+	urls      S3URLOptions
 }
 
-func NewS3Provider(cfg *appconfig.Config) *S3Provider {
+func NewS3Provider(cfg *appconfig.Config, bucket string, urls S3URLOptions) *S3Provider {
+	urls.BaseURL = strings.TrimSuffix(urls.BaseURL, "/")
+
 	awsCfg, err := config.LoadDefaultConfig(context.TODO(),
 		config.WithRegion(cfg.AWS.Region),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
@@ -44,32 +73,59 @@ func NewS3Provider(cfg *appconfig.Config) *S3Provider {
 	})
 
 	return &S3Provider{
-		client:   client,
-		uploader: manager.NewUploader(client),
-		bucket:   cfg.AWS.S3Bucket,
-		endpoint: cfg.AWS.S3Endpoint,
+		client:    client,
+		uploader:  manager.NewUploader(client),
+		presigner: s3.NewPresignClient(client),
+		bucket:    bucket,
+		endpoint:  cfg.AWS.S3Endpoint,
+		region:    cfg.AWS.Region,
+		urls:      urls,
 	}
 }
 
-func (p *S3Provider) UploadFile(file *multipart.FileHeader, path string) (string, error) {
+// Put streams r to the bucket in parts, so content of unknown size is never
+// held in memory in full
+func (p *S3Provider) Put(path string, r io.Reader, opts interfaces.PutOptions) (string, error) {
+	counter := &countingReader{r: r}
+
+	input := &s3.PutObjectInput{
+		Bucket:   aws.String(p.bucket),
+		Key:      aws.String(path),
+		Body:     counter,
+		Metadata: opts.Metadata,
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.CacheControl != "" {
+		input.CacheControl = aws.String(opts.CacheControl)
+	}
 
-	src, err := file.Open()
+	result, err := p.uploader.Upload(context.TODO(), input)
 	if err != nil {
 		return "", err
 	}
-	defer src.Close()
 
-	result, err := p.uploader.Upload(context.TODO(), &s3.PutObjectInput{
+	if opts.Size >= 0 && counter.n != opts.Size {
+		if err := p.DeleteFile(path); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: read %d bytes, expected %d", interfaces.ErrSizeMismatch, counter.n, opts.Size)
+	}
+
+	return *result.Key, nil
+}
+
+func (p *S3Provider) Open(path string) (io.ReadCloser, error) {
+	result, err := p.client.GetObject(context.TODO(), &s3.GetObjectInput{
 		Bucket: aws.String(p.bucket),
-		Key:    aws.String(path),
-		Body:   src,
+		Key:    aws.String(strings.TrimPrefix(path, "/")),
 	})
-
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return *result.Key, nil
+	return result.Body, nil
 }
 
 func (p *S3Provider) DeleteFile(path string) error {
@@ -80,3 +136,124 @@ func (p *S3Provider) DeleteFile(path string) error {
 
 	return err
 }
+
+// PresignUpload signs a PUT of the object. The content type and length are
+// part of the signature, so S3 rejects an upload of anything else.
+func (p *S3Provider) PresignUpload(path, contentType string, size int64, expires time.Duration) (*interfaces.PresignedRequest, error) {
+	request, err := p.presigner.PresignPutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:        aws.String(p.bucket),
+		Key:           aws.String(path),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(request.SignedHeader))
+	for name, values := range request.SignedHeader {
+		if strings.EqualFold(name, "Host") || len(values) == 0 {
+			continue
+		}
+		headers[name] = values[0]
+	}
+
+	return &interfaces.PresignedRequest{URL: request.URL, Method: request.Method, Headers: headers}, nil
+}
+
+func (p *S3Provider) Stat(path string) (*interfaces.ObjectInfo, error) {
+	result, err := p.client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(strings.TrimPrefix(path, "/")),
+	})
+	if err != nil {
+		var responseErr *awshttp.ResponseError
+		if errors.As(err, &responseErr) && responseErr.HTTPStatusCode() == http.StatusNotFound {
+			return nil, interfaces.ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return &interfaces.ObjectInfo{
+		Size:        aws.ToInt64(result.ContentLength),
+		ContentType: aws.ToString(result.ContentType),
+		ModifiedAt:  aws.ToTime(result.LastModified),
+		Metadata:    result.Metadata,
+	}, nil
+}
+
+func (p *S3Provider) List(prefix string) ([]interfaces.StoredObject, error) {
+	var objects []interfaces.StoredObject
+
+	paginator := s3.NewListObjectsV2Paginator(p.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(p.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range page.Contents {
+			objects = append(objects, interfaces.StoredObject{
+				Key:        aws.ToString(object.Key),
+				Size:       aws.ToInt64(object.Size),
+				ModifiedAt: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+
+	return objects, nil
+}
+
+func (p *S3Provider) URL(path string) (string, error) {
+	if isAbsoluteURL(path) {
+		return path, nil
+	}
+
+	key := strings.TrimPrefix(path, "/")
+
+	switch {
+	case p.urls.Private:
+		return "", interfaces.ErrNoPublicURL
+	case p.urls.Signed:
+		request, err := p.presigner.PresignGetObject(context.TODO(), &s3.GetObjectInput{
+			Bucket: aws.String(p.bucket),
+			Key:    aws.String(key),
+		}, s3.WithPresignExpires(p.urls.TTL))
+		if err != nil {
+			return "", err
+		}
+		return request.URL, nil
+	case p.urls.BaseURL != "":
+		return p.urls.BaseURL + "/" + escapeKey(key), nil
+	case p.endpoint != "":
+		// Path style, as used with localstack
+		return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(p.endpoint, "/"), p.bucket, escapeKey(key)), nil
+	default:
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", p.bucket, p.region, escapeKey(key)), nil
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// escapeKey escapes each segment of an object key for use in a URL path
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -1,10 +1,13 @@
 package server
 
 import (
+	"errors"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 )
 
@@ -13,6 +16,10 @@ import (
 // @Tags Cart
 // @Produce json
 // @Security BearerAuth
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart retrieved successfully"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 404 {object} utils.Response "Cart not found"
@@ -20,7 +27,7 @@ import (
 func (s *Server) getCart(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
-	cart, err := s.cartService.GetCart(userID)
+	cart, err := s.cartService.GetCart(userID, localization(c))
 	if err != nil {
 		utils.NotFoundResponse(c, "Cart not found")
 		return
@@ -36,6 +43,10 @@ func (s *Server) getCart(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param request body dto.AddToCartRequest true "Item to add to cart"
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Item added to cart successfully"
 // @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
@@ -50,7 +61,7 @@ func (s *Server) addToCart(c *gin.Context) {
 		return
 	}
 
-	cart, err := s.cartService.AddToCart(userID, &req)
+	cart, err := s.cartService.AddToCart(userID, &req, localization(c))
 	if err != nil {
 		utils.BadRequestResponse(c, "Failed to add item to cart", err)
 		return
@@ -67,6 +78,10 @@ func (s *Server) addToCart(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Cart Item ID"
 // @Param request body dto.UpdateCartItemRequest true "New quantity"
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart item updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
@@ -86,7 +101,7 @@ func (s *Server) updateCartItem(c *gin.Context) {
 		return
 	}
 
-	cart, err := s.cartService.UpdateCartItem(userID, uint(id), &req)
+	cart, err := s.cartService.UpdateCartItem(userID, uint(id), &req, localization(c))
 	if err != nil {
 		utils.BadRequestResponse(c, "Failed to update cart item", err)
 		return
@@ -120,3 +135,51 @@ func (s *Server) removeFromCart(c *gin.Context) {
 
 	utils.SuccessResponse(c, "Item removed from cart successfully", nil)
 }
+
+// @Summary Reserve cart stock
+// @Description Hold the stock of the cart's items while checkout is in progress. The hold expires after the configured TTL; reserving again extends it and picks up cart changes
+// @Tags Cart
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart reserved successfully"
+// @Failure 400 {object} utils.Response "Cart is empty"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Insufficient stock"
+// @Router /cart/reservation [post]
+func (s *Server) reserveCart(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	cart, err := s.cartService.ReserveCart(userID, localization(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInsufficientStock) {
+			utils.ErrorResponse(c, http.StatusConflict, "Failed to reserve cart", err)
+			return
+		}
+		utils.BadRequestResponse(c, "Failed to reserve cart", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart reserved successfully", cart)
+}
+
+// @Summary Release cart reservation
+// @Description Give back the stock held for the cart, e.g. when checkout is abandoned
+// @Tags Cart
+// @Security BearerAuth
+// @Success 200 {object} utils.Response "Reservation released successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /cart/reservation [delete]
+func (s *Server) releaseCart(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	if err := s.cartService.ReleaseCart(userID); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to release reservation", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Reservation released successfully", nil)
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary List currencies
+// @Description List the store's base currency and every currency prices can be shown in
+// @Tags Currencies
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]dto.CurrencyResponse} "Currencies retrieved successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /currencies [get]
+func (s *Server) getCurrencies(c *gin.Context) {
+	currencies, err := s.currencyService.GetCurrencies()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch currencies", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Currencies retrieved successfully", currencies)
+}
+
+// @Summary Set an exchange rate
+// @Description Add a currency or update its rate. The rate is the number of units of the currency worth one unit of the base currency (Admin only)
+// @Tags Currencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "ISO 4217 currency code"
+// @Param request body dto.SetExchangeRateRequest true "Exchange rate"
+// @Success 200 {object} utils.Response{data=dto.CurrencyResponse} "Exchange rate saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /currencies/{code} [put]
+func (s *Server) setExchangeRate(c *gin.Context) {
+	var req dto.SetExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	currency, err := s.currencyService.SetExchangeRate(c.Param("code"), &req)
+	if err != nil {
+		handleCurrencyError(c, "Failed to save exchange rate", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Exchange rate saved successfully", currency)
+}
+
+// @Summary Remove a currency
+// @Description Stop offering a currency and drop its price list. Existing orders keep their currency and rate (Admin only)
+// @Tags Currencies
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "ISO 4217 currency code"
+// @Success 200 {object} utils.Response "Currency removed successfully"
+// @Failure 400 {object} utils.Response "Invalid currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Currency not found"
+// @Router /currencies/{code} [delete]
+func (s *Server) deleteExchangeRate(c *gin.Context) {
+	if err := s.currencyService.DeleteExchangeRate(c.Param("code")); err != nil {
+		if errors.Is(err, services.ErrUnsupportedCurrency) {
+			utils.NotFoundResponse(c, "Currency not found")
+			return
+		}
+		handleCurrencyError(c, "Failed to remove currency", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Currency removed successfully", nil)
+}
+
+// @Summary Get a product's price list
+// @Description List the per-currency prices that override the converted base price of a product (Admin only)
+// @Tags Currencies
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=[]dto.ProductPriceResponse} "Prices retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/prices [get]
+func (s *Server) getProductPrices(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	prices, err := s.currencyService.GetProductPrices(uint(id))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch prices", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Prices retrieved successfully", prices)
+}
+
+// @Summary Set a product price
+// @Description Set the price of a product in a supported currency, replacing the converted base price (Admin only)
+// @Tags Currencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Param request body dto.SetProductPriceRequest true "Price in the currency"
+// @Success 200 {object} utils.Response{data=dto.ProductPriceResponse} "Price saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /products/{id}/prices/{currency} [put]
+func (s *Server) setProductPrice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.SetProductPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	price, err := s.currencyService.SetProductPrice(uint(id), c.Param("currency"), &req)
+	if err != nil {
+		handleCurrencyError(c, "Failed to save price", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Price saved successfully", price)
+}
+
+// @Summary Remove a product price
+// @Description Remove a price list entry so the product is converted from its base price again (Admin only)
+// @Tags Currencies
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 200 {object} utils.Response "Price removed successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID or currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Price not found"
+// @Router /products/{id}/prices/{currency} [delete]
+func (s *Server) deleteProductPrice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	if err := s.currencyService.DeleteProductPrice(uint(id), c.Param("currency")); err != nil {
+		handleCurrencyError(c, "Failed to remove price", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Price removed successfully", nil)
+}
+
+func handleCurrencyError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrProductPriceNotFound):
+		utils.NotFoundResponse(c, "Price not found")
+	case errors.Is(err, services.ErrInvalidCurrency),
+		errors.Is(err, services.ErrUnsupportedCurrency),
+		errors.Is(err, services.ErrBaseCurrency):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get order downloads
+// @Description Get expiring signed download links for the digital products in an order
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=[]dto.DownloadLinkResponse} "Downloads retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /orders/{id}/downloads [get]
+func (s *Server) getOrderDownloads(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	downloads, err := s.downloadService.GetOrderDownloads(userID, uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Order not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Downloads retrieved successfully", downloads)
+}
+
+// @Summary Download a digital product file
+// @Description Stream a purchased file using a signed link from the order downloads endpoint
+// @Tags Orders
+// @Produce octet-stream
+// @Param id path int true "Download ID"
+// @Param expires query int true "Link expiry as a unix timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file "File content"
+// @Failure 403 {object} utils.Response "Invalid or expired link"
+// @Failure 410 {object} utils.Response "Download limit reached"
+// @Router /downloads/{id} [get]
+func (s *Server) downloadFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid download ID", err)
+		return
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		utils.ForbiddenResponse(c, "Invalid download link")
+		return
+	}
+
+	file, err := s.downloadService.OpenDownload(uint(id), expires, c.Query("signature"))
+	switch {
+	case errors.Is(err, services.ErrInvalidDownloadLink):
+		utils.ForbiddenResponse(c, "Invalid download link")
+		return
+	case errors.Is(err, services.ErrDownloadLimitReached):
+		utils.ErrorResponse(c, http.StatusGone, "Download limit reached", nil)
+		return
+	case err != nil:
+		utils.InternalServerErrorResponse(c, "Failed to download file", err)
+		return
+	}
+	defer file.Reader.Close()
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, file.Size, contentType, file.Reader, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", file.FileName),
+		"Cache-Control":       "no-store",
+	})
+
+	// A download the buyer didn't receive in full doesn't count towards the
+	// limit
+	if int64(c.Writer.Size()) < file.Size {
+		if err := s.downloadService.ReleaseDownload(uint(id)); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to release an incomplete download")
+		}
+	}
+}
@@ -22,6 +22,13 @@ func (s *Server) createGraphQLHandler() *handler.Server {
 		s.userService,
 		s.productService, s.cartService,
 		s.orderService,
+		s.reviewService,
+		s.revisionService,
+		s.currencyService,
+		s.translationService,
+		s.warehouseService,
+		s.stockSubscriptionService,
+		s.uploadService,
 	)
 
 	schema := graph.NewExecutableSchema(graph.Config{Resolvers: rvr})
@@ -83,10 +90,14 @@ func (s *Server) graphqlMiddleware() gin.HandlerFunc {
 		userID, _ := c.Get("user_id")
 		userEmail, _ := c.Get("user_email")
 		userRole, _ := c.Get("user_role")
+		currency := c.GetString("currency")
+		locale := c.GetString("locale")
 
 		ctx := context.WithValue(c.Request.Context(), utils.UserIDKey, userID)
 		ctx = context.WithValue(ctx, utils.UserEmailKey, userEmail)
 		ctx = context.WithValue(ctx, utils.UserRoleKey, userRole)
+		ctx = context.WithValue(ctx, utils.CurrencyKey, currency)
+		ctx = context.WithValue(ctx, utils.LocaleKey, locale)
 		ctx = context.WithValue(ctx, utils.GinContextKey, c)
 
 		c.Request = c.Request.WithContext(ctx)
@@ -1,10 +1,14 @@
 package server
 
 import (
+	"errors"
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
 	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/services"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 )
 
@@ -58,3 +62,76 @@ func (s *Server) adminMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// currencyMiddleware resolves the currency prices are shown in from the
+// currency query parameter or the Accept-Currency header, defaulting to the
+// store's base currency
+func (s *Server) currencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		code := c.Query("currency")
+		if code == "" {
+			code = c.GetHeader("Accept-Currency")
+		}
+
+		currency, err := s.currencyService.ResolveCurrency(code)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidCurrency) || errors.Is(err, services.ErrUnsupportedCurrency) {
+				utils.BadRequestResponse(c, "Unsupported currency", err)
+			} else {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resolve currency", err)
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("currency", currency)
+
+		c.Next()
+	}
+}
+
+// localeMiddleware resolves the locale catalog content is shown in from the
+// locale query parameter or, failing that, the best supported language of the
+// Accept-Language header. Content falls back to the default locale.
+func (s *Server) localeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale, err := s.translationService.ResolveLocale(c.Query("locale"))
+		if err != nil {
+			utils.BadRequestResponse(c, "Unsupported locale", err)
+			c.Abort()
+			return
+		}
+
+		if c.Query("locale") == "" {
+			locale = s.acceptedLocale(c.GetHeader("Accept-Language"), locale)
+		}
+
+		c.Set("locale", locale)
+
+		c.Next()
+	}
+}
+
+// acceptedLocale returns the first supported locale of an Accept-Language
+// header, trying a tag's primary language when the tag itself (e.g. fr-CA) is
+// not supported
+func (s *Server) acceptedLocale(header, fallback string) string {
+	for _, tag := range utils.AcceptedLanguages(header) {
+		language, _, _ := strings.Cut(tag, "-")
+		for _, candidate := range []string{tag, language} {
+			if locale, err := s.translationService.ResolveLocale(candidate); err == nil {
+				return locale
+			}
+		}
+	}
+
+	return fallback
+}
+
+// localization returns the currency and locale resolved for the request
+func localization(c *gin.Context) dto.Localization {
+	return dto.Localization{
+		Currency: c.GetString("currency"),
+		Locale:   c.GetString("locale"),
+	}
+}
@@ -1,18 +1,26 @@
 package server
 
 import (
+	"errors"
+	"io"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/dto"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 )
 
 // @Summary Create an order
-// @Description Create an order from the current user's cart
+// @Description Create an order from the current user's cart. The optional shipping location is used to ship from the closest warehouses
 // @Tags Orders
+// @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param request body dto.CreateOrderRequest false "Shipping location"
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 201 {object} utils.Response{data=dto.OrderResponse} "Order created successfully"
 // @Failure 400 {object} utils.Response "Cart is empty or insufficient stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
@@ -20,7 +28,13 @@ import (
 func (s *Server) createOrder(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
-	order, err := s.orderService.CreateOrder(userID)
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.CreateOrder(userID, &req, localization(c))
 	if err != nil {
 		utils.BadRequestResponse(c, "Failed to create order", err)
 		return
@@ -36,6 +50,8 @@ func (s *Server) createOrder(c *gin.Context) {
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.OrderResponse} "Orders retrieved successfully"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 500 {object} utils.Response "Internal server error"
@@ -46,7 +62,7 @@ func (s *Server) getOrders(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	orders, meta, err := s.orderService.GetOrders(userID, page, limit)
+	orders, meta, err := s.orderService.GetOrders(userID, page, limit, c.GetString("locale"))
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to fetch orders", err)
 		return
@@ -61,6 +77,8 @@ func (s *Server) getOrders(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Order ID"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.Response{data=dto.OrderResponse} "Order retrieved successfully"
 // @Failure 400 {object} utils.Response "Invalid order ID"
 // @Failure 401 {object} utils.Response "Unauthorized"
@@ -75,7 +93,7 @@ func (s *Server) getOrder(c *gin.Context) {
 		return
 	}
 
-	order, err := s.orderService.GetOrder(userID, uint(id))
+	order, err := s.orderService.GetOrder(userID, uint(id), c.GetString("locale"))
 	if err != nil {
 		utils.NotFoundResponse(c, "Order not found")
 		return
@@ -2,13 +2,20 @@ package server
 
 import (
 	"errors"
+	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 )
 
+// didYouMeanHeader carries the corrected query of a search that looks
+// misspelled
+const didYouMeanHeader = "X-Did-You-Mean"
+
 // @Summary Create a new category
 // @Description Create a new product category (Admin only)
 // @Tags Categories
@@ -28,7 +35,7 @@ func (s *Server) createCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := s.productService.CreateCategory(&req)
+	category, err := s.productService.CreateCategory(c.GetUint("user_id"), &req)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to create category", err)
 		return
@@ -42,11 +49,13 @@ func (s *Server) createCategory(c *gin.Context) {
 // @Description Retrieve all active categories
 // @Tags Categories
 // @Produce json
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.Response{data=[]dto.CategoryResponse} "Categories retrieved successfully"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /categories [get]
 func (s *Server) getCategories(c *gin.Context) {
-	categories, err := s.productService.GetCategories()
+	categories, err := s.productService.GetCategories(c.GetString("locale"))
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to fetch categories", err)
 		return
@@ -81,7 +90,7 @@ func (s *Server) updateCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := s.productService.UpdateCategory(uint(id), &req)
+	category, err := s.productService.UpdateCategory(c.GetUint("user_id"), uint(id), &req)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to update category", err)
 		return
@@ -92,7 +101,7 @@ func (s *Server) updateCategory(c *gin.Context) {
 }
 
 // @Summary Delete a category
-// @Description Delete a category (Admin only)
+// @Description Move a category and its products to the trash (Admin only)
 // @Tags Categories
 // @Security BearerAuth
 // @Param id path int true "Category ID"
@@ -100,6 +109,7 @@ func (s *Server) updateCategory(c *gin.Context) {
 // @Failure 400 {object} utils.Response "Invalid category ID"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Category not found"
 // @Router /categories/{id} [delete]
 func (s *Server) deleteCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -108,7 +118,11 @@ func (s *Server) deleteCategory(c *gin.Context) {
 		return
 	}
 
-	if err := s.productService.DeleteCategory(uint(id)); err != nil {
+	if err := s.productService.DeleteCategory(c.GetUint("user_id"), uint(id)); err != nil {
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			utils.NotFoundResponse(c, "Category not found")
+			return
+		}
 		utils.InternalServerErrorResponse(c, "Failed to delete category", err)
 		return
 	}
@@ -134,8 +148,12 @@ func (s *Server) createProduct(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid request data", err)
 		return
 	}
-	product, err := s.productService.CreateProduct(&req)
+	product, err := s.productService.CreateProduct(c.GetUint("user_id"), &req)
 	if err != nil {
+		if errors.Is(err, services.ErrPreorderShipDate) {
+			utils.BadRequestResponse(c, "Failed to create product", err)
+			return
+		}
 		utils.InternalServerErrorResponse(c, "Failed to create product", err)
 		return
 	}
@@ -149,14 +167,25 @@ func (s *Server) createProduct(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param sort query string false "Sort order" Enums(newest, rating)
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.ProductResponse} "Products retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /products [get]
 func (s *Server) getProducts(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	var req dto.ListProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
 
-	products, meta, err := s.productService.GetProducts(page, limit)
+	req.Localization = localization(c)
+
+	products, meta, err := s.productService.GetProducts(&req)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to fetch products", err)
 		return
@@ -170,6 +199,10 @@ func (s *Server) getProducts(c *gin.Context) {
 // @Tags Products
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.Response{data=dto.ProductResponse} "Product retrieved successfully"
 // @Failure 400 {object} utils.Response "Invalid product ID"
 // @Failure 404 {object} utils.Response "Product not found"
@@ -181,7 +214,7 @@ func (s *Server) getProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := s.productService.GetProduct(uint(id))
+	product, err := s.productService.GetProduct(uint(id), localization(c))
 	if err != nil {
 		utils.NotFoundResponse(c, "Product not found")
 		return
@@ -216,8 +249,12 @@ func (s *Server) updateProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := s.productService.UpdateProduct(uint(id), &req)
+	product, err := s.productService.UpdateProduct(c.GetUint("user_id"), uint(id), &req)
 	if err != nil {
+		if errors.Is(err, services.ErrPreorderShipDate) {
+			utils.BadRequestResponse(c, "Failed to update product", err)
+			return
+		}
 		utils.InternalServerErrorResponse(c, "Failed to update product", err)
 		return
 	}
@@ -234,6 +271,7 @@ func (s *Server) updateProduct(c *gin.Context) {
 // @Failure 400 {object} utils.Response "Invalid product ID"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
 // @Router /products/{id} [delete]
 func (s *Server) deleteProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -241,7 +279,11 @@ func (s *Server) deleteProduct(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid product ID", err)
 		return
 	}
-	if err := s.productService.DeleteProduct(uint(id)); err != nil {
+	if err := s.productService.DeleteProduct(c.GetUint("user_id"), uint(id)); err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			utils.NotFoundResponse(c, "Product not found")
+			return
+		}
 		utils.InternalServerErrorResponse(c, "Failed to delete product", err)
 		return
 	}
@@ -249,6 +291,68 @@ func (s *Server) deleteProduct(c *gin.Context) {
 	utils.SuccessResponse(c, "Product deleted successfully", nil)
 }
 
+// @Summary Configure a product bundle
+// @Description Turn a product into a bundle of existing products, or replace its components (Admin only)
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.SetBundleRequest true "Bundle components and pricing"
+// @Success 200 {object} utils.Response{data=dto.ProductResponse} "Bundle updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/bundle [put]
+func (s *Server) setProductBundle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.SetBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	product, err := s.productService.SetBundle(c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to update bundle", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Bundle updated successfully", product)
+}
+
+// @Summary Remove a product bundle
+// @Description Remove all components and turn a bundle back into a regular product (Admin only)
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=dto.ProductResponse} "Bundle removed successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/bundle [delete]
+func (s *Server) removeProductBundle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	product, err := s.productService.RemoveBundle(c.GetUint("user_id"), uint(id))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to remove bundle", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Bundle removed successfully", product)
+}
+
 // @Summary Upload product image
 // @Description Upload an image for a product (Admin only)
 // @Tags Products
@@ -257,10 +361,12 @@ func (s *Server) deleteProduct(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Product ID"
 // @Param image formData file true "Image file"
-// @Success 200 {object} utils.Response{data=map[string]string} "Image uploaded successfully"
-// @Failure 400 {object} utils.Response "Invalid request or file"
+// @Param alt_text formData string false "Alternative text describing the image"
+// @Success 200 {object} utils.Response{data=dto.ProductImageResponse} "Image uploaded successfully"
+// @Failure 400 {object} utils.Response{data=services.UploadValidationError} "Invalid request or file"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
 // @Router /products/{id}/images [post]
 func (s *Server) uploadProductImage(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -275,31 +381,246 @@ func (s *Server) uploadProductImage(c *gin.Context) {
 		return
 	}
 
-	url, err := s.uploadService.UploadProductImage(uint(id), file)
+	uploaded, err := s.uploadService.UploadProductImage(uint(id), file)
+	if err != nil {
+		handleUploadError(c, "Failed to upload image", err)
+		return
+	}
+
+	image, err := s.productService.AddProductImage(uint(id), uploaded, c.PostForm("alt_text"))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to save image record", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Image uploaded successfully", image)
+}
+
+// @Summary Import a product image from a URL
+// @Description Fetch an image from a URL and add it to the product (Admin only). The fetch is bounded in time and size.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.ImportProductImageRequest true "Image URL"
+// @Success 200 {object} utils.Response{data=dto.ProductImageResponse} "Image imported successfully"
+// @Failure 400 {object} utils.Response{data=services.UploadValidationError} "Invalid request, or the image could not be fetched"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /products/{id}/images/import [post]
+func (s *Server) importProductImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.ImportProductImageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	uploaded, err := s.uploadService.ImportProductImage(uint(id), req.URL)
+	if err != nil {
+		handleUploadError(c, "Failed to import image", err)
+		return
+	}
+
+	image, err := s.productService.AddProductImage(uint(id), uploaded, req.AltText)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to save image record", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Image imported successfully", image)
+}
+
+// @Summary Presign a direct image upload
+// @Description Get a URL to upload a product image straight to storage, then confirm it (Admin only). The upload must have exactly the declared content type and size.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.PresignImageUploadRequest true "Image to upload"
+// @Success 201 {object} utils.Response{data=dto.PresignedUploadResponse} "Upload URL created"
+// @Failure 400 {object} utils.Response{data=services.UploadValidationError} "Invalid request or file"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 501 {object} utils.Response "Storage does not support direct uploads"
+// @Router /products/{id}/images/presign [post]
+func (s *Server) presignProductImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.PresignImageUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	upload, err := s.uploadService.PresignProductImage(uint(id), &req)
+	if err != nil {
+		handleUploadError(c, "Failed to create upload URL", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Upload URL created", upload)
+}
+
+// @Summary Confirm a direct image upload
+// @Description Add an image uploaded with a presigned URL to the product (Admin only)
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.ConfirmImageUploadRequest true "Upload to confirm"
+// @Success 200 {object} utils.Response{data=dto.ProductImageResponse} "Image uploaded successfully"
+// @Failure 400 {object} utils.Response{data=services.UploadValidationError} "Invalid request or file"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Upload not found"
+// @Failure 409 {object} utils.Response "Upload has not been received"
+// @Failure 501 {object} utils.Response "Storage does not support direct uploads"
+// @Router /products/{id}/images/confirm [post]
+func (s *Server) confirmProductImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.ConfirmImageUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	uploaded, err := s.uploadService.ConfirmProductImage(uint(id), req.UploadID)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to upload image", err)
+		handleUploadError(c, "Failed to confirm upload", err)
 		return
 	}
 
-	if err := s.productService.AddProductImage(uint(id), url, file.Filename); err != nil {
+	image, err := s.productService.AddProductImage(uint(id), uploaded, req.AltText)
+	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to save image record", err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Image uploaded successfully", map[string]string{"url": url})
+	utils.SuccessResponse(c, "Image uploaded successfully", image)
+}
+
+// @Summary Upload a digital product file
+// @Description Upload a privately stored file delivered to buyers of a digital product (Admin only)
+// @Tags Products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param file formData file true "Product file"
+// @Success 201 {object} utils.Response{data=dto.ProductFileResponse} "File uploaded successfully"
+// @Failure 400 {object} utils.Response{data=services.UploadValidationError} "Invalid request, file too large or product is not digital"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/files [post]
+func (s *Server) uploadProductFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequestResponse(c, "No file uploaded", err)
+		return
+	}
+
+	key, err := s.uploadService.UploadProductFile(uint(id), file)
+	if err != nil {
+		handleUploadError(c, "Failed to upload file", err)
+		return
+	}
+
+	productFile, err := s.productService.AddProductFile(uint(id), key, file.Filename, file.Header.Get("Content-Type"), file.Size)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to save file record", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "File uploaded successfully", productFile)
+}
+
+func handleUploadError(c *gin.Context, message string, err error) {
+	var validationErr *services.UploadValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		utils.ValidationErrorResponse(c, message, err, validationErr)
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrPendingUploadNotFound):
+		utils.NotFoundResponse(c, "Upload not found")
+	case errors.Is(err, services.ErrUploadNotReceived):
+		utils.ErrorResponse(c, http.StatusConflict, message, err)
+	case errors.Is(err, services.ErrDirectUploadUnsupported):
+		utils.ErrorResponse(c, http.StatusNotImplemented, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
+
+// @Summary List digital product files
+// @Description List the files delivered to buyers of a digital product (Admin only)
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=[]dto.ProductFileResponse} "Files retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/files [get]
+func (s *Server) getProductFiles(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	files, err := s.productService.GetProductFiles(uint(id))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch files", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Files retrieved successfully", files)
 }
 
 // @Summary Search products
-// @Description Search products using full-text search with ranking
+// @Description Search products using full-text search with ranking, in the requested locale. When few products match, products with similar names or SKUs are also returned, flagged as fuzzy, and a corrected query is sent in the X-Did-You-Mean header if the search looks misspelled.
 // @Tags Products
 // @Produce json
 // @Param q query string true "Search query"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param category_id query int false "Filter by category ID"
-// @Param min_price query number false "Minimum price filter"
-// @Param max_price query number false "Maximum price filter"
+// @Param min_price query number false "Minimum price filter, in the requested currency"
+// @Param max_price query number false "Maximum price filter, in the requested currency"
+// @Param currency query string false "Currency code, overrides the Accept-Currency header"
+// @Param Accept-Currency header string false "Currency code, defaults to the store's base currency"
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.ProductSearchResult} "Search results"
+// @Header 200 {string} X-Did-You-Mean "Corrected query, percent-encoded, when the search looks misspelled"
 // @Failure 400 {object} utils.Response "Invalid search query"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /search [get]
@@ -310,12 +631,50 @@ func (s *Server) searchProducts(c *gin.Context) {
 		return
 	}
 
-	results, meta, err := s.productService.SearchProducts(&req)
+	req.Localization = localization(c)
+
+	results, meta, didYouMean, err := s.productService.SearchProducts(&req)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Product search failed")
 		utils.InternalServerErrorResponse(c, "Search failed", errors.New("unable to complete search at this time"))
 		return
 	}
 
+	// Header values are ASCII, so the words of the query are percent-encoded
+	if didYouMean != "" {
+		c.Header(didYouMeanHeader, url.PathEscape(didYouMean))
+	}
+
 	utils.PaginatedSuccessResponse(c, "OK", results, *meta)
 }
+
+// @Summary Suggest searches
+// @Description Typeahead suggestions for a partial search query: products and categories by name, in the requested locale, and popular searches
+// @Tags Products
+// @Produce json
+// @Param q query string true "Partial search query"
+// @Param limit query int false "Suggestions of each kind" default(5)
+// @Param locale query string false "Locale, overrides the Accept-Language header"
+// @Param Accept-Language header string false "Preferred languages, content falls back to the default locale"
+// @Success 200 {object} utils.Response{data=dto.SearchSuggestionsResponse} "Search suggestions"
+// @Failure 400 {object} utils.Response "Invalid search query"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /search/suggest [get]
+func (s *Server) suggestSearch(c *gin.Context) {
+	var req dto.SearchSuggestRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid search parameters", err)
+		return
+	}
+
+	req.Localization = localization(c)
+
+	suggestions, err := s.productService.SuggestSearch(&req)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Search suggestions failed")
+		utils.InternalServerErrorResponse(c, "Search suggestions failed", errors.New("unable to suggest searches at this time"))
+		return
+	}
+
+	utils.SuccessResponse(c, "OK", suggestions)
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Update a product image
+// @Description Edit the alternative text of a product image (Admin only)
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param imageId path int true "Image ID"
+// @Param request body dto.UpdateProductImageRequest true "Image details"
+// @Success 200 {object} utils.Response{data=dto.ProductImageResponse} "Image updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Image not found"
+// @Router /products/{id}/images/{imageId} [put]
+func (s *Server) updateProductImage(c *gin.Context) {
+	productID, imageID, ok := parseProductImageIDs(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateProductImageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	image, err := s.productService.UpdateProductImage(productID, imageID, &req)
+	if err != nil {
+		handleProductImageError(c, "Failed to update image", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Image updated successfully", image)
+}
+
+// @Summary Delete a product image
+// @Description Delete a product image and its stored files (Admin only). If it was the primary image, the next image becomes primary.
+// @Tags Products
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param imageId path int true "Image ID"
+// @Success 200 {object} utils.Response "Image deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Image not found"
+// @Router /products/{id}/images/{imageId} [delete]
+func (s *Server) deleteProductImage(c *gin.Context) {
+	productID, imageID, ok := parseProductImageIDs(c)
+	if !ok {
+		return
+	}
+
+	paths, err := s.productService.DeleteProductImage(productID, imageID)
+	if err != nil {
+		handleProductImageError(c, "Failed to delete image", err)
+		return
+	}
+
+	s.uploadService.DeleteFiles(paths)
+
+	utils.SuccessResponse(c, "Image deleted successfully", nil)
+}
+
+// @Summary Set the primary product image
+// @Description Make an image the product's primary image, in place of the current one (Admin only)
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param imageId path int true "Image ID"
+// @Success 200 {object} utils.Response{data=[]dto.ProductImageResponse} "Primary image updated successfully"
+// @Failure 400 {object} utils.Response "Invalid ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Image not found"
+// @Router /products/{id}/images/{imageId}/primary [put]
+func (s *Server) setPrimaryProductImage(c *gin.Context) {
+	productID, imageID, ok := parseProductImageIDs(c)
+	if !ok {
+		return
+	}
+
+	images, err := s.productService.SetPrimaryProductImage(productID, imageID)
+	if err != nil {
+		handleProductImageError(c, "Failed to set primary image", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Primary image updated successfully", images)
+}
+
+// @Summary Reorder product images
+// @Description Set the order a product's images are shown in. Every image of the product must be listed once. (Admin only)
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.ReorderProductImagesRequest true "Image IDs in order"
+// @Success 200 {object} utils.Response{data=[]dto.ProductImageResponse} "Images reordered successfully"
+// @Failure 400 {object} utils.Response "Invalid request or image order"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /products/{id}/images/order [put]
+func (s *Server) reorderProductImages(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.ReorderProductImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	images, err := s.productService.ReorderProductImages(uint(productID), req.ImageIDs)
+	if err != nil {
+		handleProductImageError(c, "Failed to reorder images", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Images reordered successfully", images)
+}
+
+func parseProductImageIDs(c *gin.Context) (uint, uint, bool) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return 0, 0, false
+	}
+
+	imageID, err := strconv.ParseUint(c.Param("imageId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid image ID", err)
+		return 0, 0, false
+	}
+
+	return uint(productID), uint(imageID), true
+}
+
+func handleProductImageError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrProductImageNotFound):
+		utils.NotFoundResponse(c, "Image not found")
+	case errors.Is(err, services.ErrInvalidImageOrder):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get product reviews
+// @Description Retrieve paginated approved reviews for a product
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param sort query string false "Sort order" Enums(newest, helpful, rating_desc, rating_asc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.ReviewResponse} "Reviews retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /products/{id}/reviews [get]
+func (s *Server) getProductReviews(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.ListReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	reviews, meta, err := s.reviewService.GetProductReviews(uint(id), &req)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch reviews", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Reviews retrieved successfully", reviews, *meta)
+}
+
+// @Summary Review a product
+// @Description Submit a 1-5 star review for a product. Reviews are published after moderation.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.CreateReviewRequest true "Review data"
+// @Success 201 {object} utils.Response{data=dto.ReviewResponse} "Review submitted successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Product already reviewed"
+// @Router /products/{id}/reviews [post]
+func (s *Server) createReview(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	review, err := s.reviewService.CreateReview(userID, uint(id), &req)
+	if errors.Is(err, services.ErrAlreadyReviewed) {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to submit review", err)
+		return
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to submit review", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Review submitted successfully", review)
+}
+
+// @Summary Mark a review as helpful
+// @Description Vote for an approved review. Voting more than once has no effect.
+// @Tags Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Success 200 {object} utils.Response{data=dto.ReviewResponse} "Vote recorded successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Router /reviews/{id}/helpful [post]
+func (s *Server) markReviewHelpful(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid review ID", err)
+		return
+	}
+
+	review, err := s.reviewService.MarkHelpful(userID, uint(id))
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to record vote", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Vote recorded successfully", review)
+}
+
+// @Summary Get the review moderation queue
+// @Description Retrieve paginated reviews by moderation status, oldest first (Admin only)
+// @Tags Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Review status" Enums(pending, approved, rejected) default(pending)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.ReviewResponse} "Reviews retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid status"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Router /reviews [get]
+func (s *Server) getReviewQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	reviews, meta, err := s.reviewService.GetReviewsByStatus(c.Query("status"), page, limit)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to fetch reviews", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Reviews retrieved successfully", reviews, *meta)
+}
+
+// @Summary Moderate a review
+// @Description Approve or reject a review and update the product rating (Admin only)
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Param request body dto.ModerateReviewRequest true "Moderation decision"
+// @Success 200 {object} utils.Response{data=dto.ReviewResponse} "Review moderated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden"
+// @Failure 404 {object} utils.Response "Review not found"
+// @Router /reviews/{id}/moderation [put]
+func (s *Server) moderateReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid review ID", err)
+		return
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	review, err := s.reviewService.ModerateReview(uint(id), &req)
+	if err != nil {
+		utils.NotFoundResponse(c, "Review not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Review moderated successfully", review)
+}
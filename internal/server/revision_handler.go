@@ -0,0 +1,124 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get product history
+// @Description Retrieve the revision history of a product, newest first (Admin only)
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.RevisionResponse} "Revisions retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/revisions [get]
+func (s *Server) getProductRevisions(c *gin.Context) {
+	s.getRevisions(c, services.RevisionEntityProduct, "Invalid product ID")
+}
+
+// @Summary Get category history
+// @Description Retrieve the revision history of a category, newest first (Admin only)
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.RevisionResponse} "Revisions retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid category ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /categories/{id}/revisions [get]
+func (s *Server) getCategoryRevisions(c *gin.Context) {
+	s.getRevisions(c, services.RevisionEntityCategory, "Invalid category ID")
+}
+
+func (s *Server) getRevisions(c *gin.Context, entityType, invalidIDMessage string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, invalidIDMessage, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	revisions, meta, err := s.revisionService.GetRevisions(entityType, uint(id), page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch revisions", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Revisions retrieved successfully", revisions, *meta)
+}
+
+// @Summary Get a revision
+// @Description Retrieve a single revision with its diff and snapshot (Admin only)
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Revision ID"
+// @Success 200 {object} utils.Response{data=dto.RevisionResponse} "Revision retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid revision ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Revision not found"
+// @Router /revisions/{id} [get]
+func (s *Server) getRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid revision ID", err)
+		return
+	}
+
+	revision, err := s.revisionService.GetRevision(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Revision not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Revision retrieved successfully", revision)
+}
+
+// @Summary Roll back to a revision
+// @Description Restore a product or category to the state recorded in a revision. The rollback is recorded as a new revision (Admin only)
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Revision ID"
+// @Success 200 {object} utils.Response{data=dto.RevisionResponse} "Rolled back successfully"
+// @Failure 400 {object} utils.Response "Revision cannot be applied"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Revision not found"
+// @Router /revisions/{id}/rollback [post]
+func (s *Server) rollbackRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid revision ID", err)
+		return
+	}
+
+	revision, err := s.revisionService.Rollback(c.GetUint("user_id"), uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrRevisionNotFound) {
+			utils.NotFoundResponse(c, "Revision not found")
+			return
+		}
+		utils.BadRequestResponse(c, "Failed to roll back", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Rolled back successfully", revision)
+}
@@ -2,10 +2,12 @@ package server
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/joefazee/learning-go-shop/docs"
 	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
 	"github.com/joefazee/learning-go-shop/internal/services"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
@@ -13,14 +15,23 @@ import (
 )
 
 type Server struct {
-	config         *config.Config
-	logger         *zerolog.Logger
-	authService    services.AuthServiceInterface
-	productService services.ProductServiceInterface
-	userService    services.UserServiceInterface
-	uploadService  services.UploadServiceInterface
-	cartService    services.CartServiceInterface
-	orderService   services.OrderServiceInterface
+	config             *config.Config
+	logger             *zerolog.Logger
+	authService        services.AuthServiceInterface
+	productService     services.ProductServiceInterface
+	userService        services.UserServiceInterface
+	uploadService      services.UploadServiceInterface
+	cartService        services.CartServiceInterface
+	orderService       services.OrderServiceInterface
+	downloadService    services.DownloadServiceInterface
+	reviewService      services.ReviewServiceInterface
+	trashService       services.TrashServiceInterface
+	revisionService    services.RevisionServiceInterface
+	currencyService    services.CurrencyServiceInterface
+	translationService services.TranslationServiceInterface
+	warehouseService   services.WarehouseServiceInterface
+
+	stockSubscriptionService services.StockSubscriptionServiceInterface
 }
 
 func New(cfg *config.Config,
@@ -31,16 +42,33 @@ func New(cfg *config.Config,
 	uploadService services.UploadServiceInterface,
 	cartService services.CartServiceInterface,
 	orderService services.OrderServiceInterface,
+	downloadService services.DownloadServiceInterface,
+	reviewService services.ReviewServiceInterface,
+	trashService services.TrashServiceInterface,
+	revisionService services.RevisionServiceInterface,
+	currencyService services.CurrencyServiceInterface,
+	translationService services.TranslationServiceInterface,
+	warehouseService services.WarehouseServiceInterface,
+	stockSubscriptionService services.StockSubscriptionServiceInterface,
 ) *Server {
 	return &Server{
-		config:         cfg,
-		logger:         logger,
-		authService:    authService,
-		productService: productService,
-		userService:    userService,
-		uploadService:  uploadService,
-		cartService:    cartService,
-		orderService:   orderService,
+		config:             cfg,
+		logger:             logger,
+		authService:        authService,
+		productService:     productService,
+		userService:        userService,
+		uploadService:      uploadService,
+		cartService:        cartService,
+		orderService:       orderService,
+		downloadService:    downloadService,
+		reviewService:      reviewService,
+		trashService:       trashService,
+		revisionService:    revisionService,
+		currencyService:    currencyService,
+		translationService: translationService,
+		warehouseService:   warehouseService,
+
+		stockSubscriptionService: stockSubscriptionService,
 	}
 }
 
@@ -60,22 +88,29 @@ func (s *Server) SetupRoutes() *gin.Engine {
 
 	router.StaticFile("/api-docs", "./docs/rapidoc.html")
 
-	router.Static("/uploads", "./uploads")
+	router.GET("/uploads/*filepath", serveUploads(s.config.Upload.Path))
+	router.HEAD("/uploads/*filepath", serveUploads(s.config.Upload.Path))
 
 	router.GET("/playground", s.playgroundHandler())
 	router.GET("/playground/public", s.playgroundPublicHandler())
 	router.GET("/playground/protected", s.playgroundProtectedHandler())
 
 	graphqlPublic := router.Group("/graphql/public")
+	graphqlPublic.Use(s.currencyMiddleware())
+	graphqlPublic.Use(s.localeMiddleware())
 	graphqlPublic.Use(s.graphqlMiddleware())
 	graphqlPublic.POST("/", s.graphqlHandler())
 
 	graphqlProtected := router.Group("/graphql")
 	graphqlProtected.Use(s.authMiddleware())
+	graphqlProtected.Use(s.currencyMiddleware())
+	graphqlProtected.Use(s.localeMiddleware())
 	graphqlProtected.Use(s.graphqlMiddleware())
 	graphqlProtected.POST("/", s.graphqlHandler())
 
 	api := router.Group("/api/v1")
+	api.Use(s.currencyMiddleware())
+	api.Use(s.localeMiddleware())
 	{
 		auth := api.Group("/auth")
 		{ //nolint:gocritic // I need this for readability
@@ -104,6 +139,10 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				categoryRoute.POST("/", s.adminMiddleware(), s.createCategory)
 				categoryRoute.PUT("/:id", s.adminMiddleware(), s.updateCategory)
 				categoryRoute.DELETE("/:id", s.adminMiddleware(), s.deleteCategory)
+				categoryRoute.GET("/:id/revisions", s.adminMiddleware(), s.getCategoryRevisions)
+				categoryRoute.GET("/:id/translations", s.adminMiddleware(), s.getCategoryTranslations)
+				categoryRoute.PUT("/:id/translations/:locale", s.adminMiddleware(), s.setCategoryTranslation)
+				categoryRoute.DELETE("/:id/translations/:locale", s.adminMiddleware(), s.deleteCategoryTranslation)
 			}
 
 			// product routes
@@ -114,6 +153,29 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				productRoutes.PUT("/:id", s.adminMiddleware(), s.updateProduct)
 				productRoutes.DELETE("/:id", s.adminMiddleware(), s.deleteProduct)
 				productRoutes.POST("/:id/images", s.adminMiddleware(), s.uploadProductImage)
+				productRoutes.POST("/:id/images/import", s.adminMiddleware(), s.importProductImage)
+				productRoutes.POST("/:id/images/presign", s.adminMiddleware(), s.presignProductImage)
+				productRoutes.POST("/:id/images/confirm", s.adminMiddleware(), s.confirmProductImage)
+				productRoutes.PUT("/:id/images/order", s.adminMiddleware(), s.reorderProductImages)
+				productRoutes.PUT("/:id/images/:imageId", s.adminMiddleware(), s.updateProductImage)
+				productRoutes.DELETE("/:id/images/:imageId", s.adminMiddleware(), s.deleteProductImage)
+				productRoutes.PUT("/:id/images/:imageId/primary", s.adminMiddleware(), s.setPrimaryProductImage)
+				productRoutes.GET("/:id/files", s.adminMiddleware(), s.getProductFiles)
+				productRoutes.POST("/:id/files", s.adminMiddleware(), s.uploadProductFile)
+				productRoutes.PUT("/:id/bundle", s.adminMiddleware(), s.setProductBundle)
+				productRoutes.DELETE("/:id/bundle", s.adminMiddleware(), s.removeProductBundle)
+				productRoutes.POST("/:id/reviews", s.createReview)
+				productRoutes.GET("/:id/revisions", s.adminMiddleware(), s.getProductRevisions)
+				productRoutes.GET("/:id/prices", s.adminMiddleware(), s.getProductPrices)
+				productRoutes.PUT("/:id/prices/:currency", s.adminMiddleware(), s.setProductPrice)
+				productRoutes.DELETE("/:id/prices/:currency", s.adminMiddleware(), s.deleteProductPrice)
+				productRoutes.GET("/:id/translations", s.adminMiddleware(), s.getProductTranslations)
+				productRoutes.PUT("/:id/translations/:locale", s.adminMiddleware(), s.setProductTranslation)
+				productRoutes.DELETE("/:id/translations/:locale", s.adminMiddleware(), s.deleteProductTranslation)
+				productRoutes.GET("/:id/stock", s.adminMiddleware(), s.getProductStock)
+				productRoutes.POST("/:id/stock/adjust", s.adminMiddleware(), s.adjustProductStock)
+				productRoutes.POST("/:id/stock/transfer", s.adminMiddleware(), s.transferProductStock)
+				productRoutes.GET("/:id/stock/movements", s.adminMiddleware(), s.getStockMovements)
 
 			}
 
@@ -125,6 +187,8 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				cartRoutes.POST("/items", s.addToCart)
 				cartRoutes.PUT("/items/:id", s.updateCartItem)
 				cartRoutes.DELETE("/items/:id", s.removeFromCart)
+				cartRoutes.POST("/reservation", s.reserveCart)
+				cartRoutes.DELETE("/reservation", s.releaseCart)
 			}
 
 			// Order routes
@@ -134,14 +198,86 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				orderRoutes.POST("/", s.createOrder)
 				orderRoutes.GET("/", s.getOrders)
 				orderRoutes.GET("/:id", s.getOrder)
+				orderRoutes.GET("/:id/downloads", s.getOrderDownloads)
+			}
+
+			// Review routes
+			reviews := protected.Group("/reviews")
+			{
+				reviewRoutes := reviews
+				reviewRoutes.GET("/", s.adminMiddleware(), s.getReviewQueue)
+				reviewRoutes.PUT("/:id/moderation", s.adminMiddleware(), s.moderateReview)
+				reviewRoutes.POST("/:id/helpful", s.markReviewHelpful)
+			}
+
+			// Revision routes
+			revisions := protected.Group("/revisions")
+			revisions.Use(s.adminMiddleware())
+			{
+				revisionRoutes := revisions
+				revisionRoutes.GET("/:id", s.getRevision)
+				revisionRoutes.POST("/:id/rollback", s.rollbackRevision)
+			}
+
+			// Currency routes
+			currencies := protected.Group("/currencies")
+			currencies.Use(s.adminMiddleware())
+			{
+				currencyRoutes := currencies
+				currencyRoutes.PUT("/:code", s.setExchangeRate)
+				currencyRoutes.DELETE("/:code", s.deleteExchangeRate)
+			}
+
+			// Back-in-stock subscription routes
+			stockSubscriptions := protected.Group("/stock-subscriptions")
+			{
+				stockSubscriptionRoutes := stockSubscriptions
+				stockSubscriptionRoutes.GET("/", s.getStockSubscriptions)
+				stockSubscriptionRoutes.POST("/", s.subscribeToStock)
+				stockSubscriptionRoutes.DELETE("/:id", s.cancelStockSubscription)
+			}
+
+			// Warehouse routes
+			warehouses := protected.Group("/warehouses")
+			warehouses.Use(s.adminMiddleware())
+			{
+				warehouseRoutes := warehouses
+				warehouseRoutes.GET("/", s.getWarehouses)
+				warehouseRoutes.POST("/", s.createWarehouse)
+				warehouseRoutes.PUT("/:id", s.updateWarehouse)
+				warehouseRoutes.DELETE("/:id", s.deleteWarehouse)
+			}
+
+			// Inventory routes
+			inventory := protected.Group("/inventory")
+			inventory.Use(s.adminMiddleware())
+			{
+				inventoryRoutes := inventory
+				inventoryRoutes.GET("/reconciliation", s.reconcileStock)
+			}
+
+			// Trash routes
+			trash := protected.Group("/trash")
+			trash.Use(s.adminMiddleware())
+			{
+				trashRoutes := trash
+				trashRoutes.GET("/", s.getTrash)
+				trashRoutes.POST("/products/:id/restore", s.restoreProduct)
+				trashRoutes.DELETE("/products/:id", s.purgeProduct)
+				trashRoutes.POST("/categories/:id/restore", s.restoreCategory)
+				trashRoutes.DELETE("/categories/:id", s.purgeCategory)
 			}
 		}
 
 		// public routes
 		api.GET("/categories", s.getCategories)
+		api.GET("/currencies", s.getCurrencies)
 		api.GET("/search", s.searchProducts)
+		api.GET("/search/suggest", s.suggestSearch)
 		api.GET("/products", s.getProducts)
 		api.GET("/products/:id", s.getProduct)
+		api.GET("/products/:id/reviews", s.getProductReviews)
+		api.GET("/downloads/:id", s.downloadFile)
 
 	}
 
@@ -157,7 +293,8 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept-Currency, Accept-Language")
+		c.Header("Access-Control-Expose-Headers", didYouMeanHeader)
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -168,3 +305,29 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 	}
 
 }
+
+// serveUploads serves locally stored uploads. Files named by their content hash
+// never change, so they are marked as cacheable forever.
+func serveUploads(root string) gin.HandlerFunc {
+	fs := gin.Dir(root, false)
+	fileServer := http.StripPrefix("/uploads", http.FileServer(fs))
+
+	return func(c *gin.Context) {
+		file, err := fs.Open(c.Param("filepath"))
+		if err != nil {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		info, err := file.Stat()
+		file.Close()
+		if err != nil || info.IsDir() {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		if strings.HasPrefix(c.Param("filepath"), "/"+interfaces.ContentAddressedPrefix) {
+			c.Header("Cache-Control", interfaces.ImmutableCacheControl)
+		}
+		fileServer.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get notified when a product is back in stock
+// @Description Subscribe to an email when an out of stock product is back in stock. Subscribing again has no effect.
+// @Tags Stock Subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateStockSubscriptionRequest true "Product to wait for"
+// @Success 201 {object} utils.Response{data=dto.StockSubscriptionResponse} "Subscribed successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 409 {object} utils.Response "Product is in stock"
+// @Router /stock-subscriptions [post]
+func (s *Server) subscribeToStock(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.CreateStockSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	subscription, err := s.stockSubscriptionService.Subscribe(userID, req.ProductID)
+	if err != nil {
+		handleStockSubscriptionError(c, "Failed to subscribe", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Subscribed successfully", subscription)
+}
+
+// @Summary Get back-in-stock subscriptions
+// @Description List the out of stock products the current user is waiting on
+// @Tags Stock Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.StockSubscriptionResponse} "Subscriptions retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /stock-subscriptions [get]
+func (s *Server) getStockSubscriptions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	subscriptions, err := s.stockSubscriptionService.GetSubscriptions(userID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch subscriptions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscriptions retrieved successfully", subscriptions)
+}
+
+// @Summary Cancel a back-in-stock subscription
+// @Description Stop waiting for a product to be back in stock
+// @Tags Stock Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} utils.Response "Subscription cancelled successfully"
+// @Failure 400 {object} utils.Response "Invalid subscription ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Subscription not found"
+// @Router /stock-subscriptions/{id} [delete]
+func (s *Server) cancelStockSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	if err := s.stockSubscriptionService.CancelSubscription(userID, uint(id)); err != nil {
+		handleStockSubscriptionError(c, "Failed to cancel subscription", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription cancelled successfully", nil)
+}
+
+func handleStockSubscriptionError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrStockSubscriptionNotFound):
+		utils.NotFoundResponse(c, "Subscription not found")
+	case errors.Is(err, services.ErrProductInStock):
+		utils.ErrorResponse(c, http.StatusConflict, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get product translations
+// @Description List the translations of a product's name and description (Admin only)
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=[]dto.TranslationResponse} "Translations retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/translations [get]
+func (s *Server) getProductTranslations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	translations, err := s.translationService.GetProductTranslations(uint(id))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch translations", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Translations retrieved successfully", translations)
+}
+
+// @Summary Set a product translation
+// @Description Create or replace the translation of a product into a supported locale. An empty description falls back to the default locale's (Admin only)
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param locale path string true "Locale"
+// @Param request body dto.SetTranslationRequest true "Translated content"
+// @Success 200 {object} utils.Response{data=dto.TranslationResponse} "Translation saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or locale"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /products/{id}/translations/{locale} [put]
+func (s *Server) setProductTranslation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.SetTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	translation, err := s.translationService.SetProductTranslation(uint(id), c.Param("locale"), &req)
+	if err != nil {
+		handleTranslationError(c, "Failed to save translation", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Translation saved successfully", translation)
+}
+
+// @Summary Delete a product translation
+// @Description Remove a product translation so the locale falls back to the default content (Admin only)
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param locale path string true "Locale"
+// @Success 200 {object} utils.Response "Translation deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID or locale"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Translation not found"
+// @Router /products/{id}/translations/{locale} [delete]
+func (s *Server) deleteProductTranslation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	if err := s.translationService.DeleteProductTranslation(uint(id), c.Param("locale")); err != nil {
+		handleTranslationError(c, "Failed to delete translation", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Translation deleted successfully", nil)
+}
+
+// @Summary Get category translations
+// @Description List the translations of a category's name and description (Admin only)
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.Response{data=[]dto.TranslationResponse} "Translations retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid category ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /categories/{id}/translations [get]
+func (s *Server) getCategoryTranslations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	translations, err := s.translationService.GetCategoryTranslations(uint(id))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch translations", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Translations retrieved successfully", translations)
+}
+
+// @Summary Set a category translation
+// @Description Create or replace the translation of a category into a supported locale. An empty description falls back to the default locale's (Admin only)
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param locale path string true "Locale"
+// @Param request body dto.SetTranslationRequest true "Translated content"
+// @Success 200 {object} utils.Response{data=dto.TranslationResponse} "Translation saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or locale"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Category not found"
+// @Router /categories/{id}/translations/{locale} [put]
+func (s *Server) setCategoryTranslation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	var req dto.SetTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	translation, err := s.translationService.SetCategoryTranslation(uint(id), c.Param("locale"), &req)
+	if err != nil {
+		handleTranslationError(c, "Failed to save translation", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Translation saved successfully", translation)
+}
+
+// @Summary Delete a category translation
+// @Description Remove a category translation so the locale falls back to the default content (Admin only)
+// @Tags Translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param locale path string true "Locale"
+// @Success 200 {object} utils.Response "Translation deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid category ID or locale"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Translation not found"
+// @Router /categories/{id}/translations/{locale} [delete]
+func (s *Server) deleteCategoryTranslation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	if err := s.translationService.DeleteCategoryTranslation(uint(id), c.Param("locale")); err != nil {
+		handleTranslationError(c, "Failed to delete translation", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Translation deleted successfully", nil)
+}
+
+func handleTranslationError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrCategoryNotFound):
+		utils.NotFoundResponse(c, "Category not found")
+	case errors.Is(err, services.ErrTranslationNotFound):
+		utils.NotFoundResponse(c, "Translation not found")
+	case errors.Is(err, services.ErrUnsupportedLocale), errors.Is(err, services.ErrDefaultLocale):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
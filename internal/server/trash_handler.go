@@ -0,0 +1,169 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get trash
+// @Description Retrieve paginated soft-deleted products or categories, most recently deleted first (Admin only)
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Entity type" Enums(product, category) default(product)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.TrashItemResponse} "Trash retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /trash [get]
+func (s *Server) getTrash(c *gin.Context) {
+	var req dto.ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	items, meta, err := s.trashService.GetTrash(&req)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch trash", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Trash retrieved successfully", items, *meta)
+}
+
+// @Summary Restore a product
+// @Description Restore a soft-deleted product. Its category must not be in the trash (Admin only)
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=dto.ProductResponse} "Product restored successfully"
+// @Failure 400 {object} utils.Response "Product cannot be restored"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not in trash"
+// @Router /trash/products/{id}/restore [post]
+func (s *Server) restoreProduct(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	product, err := s.trashService.RestoreProduct(c.GetUint("user_id"), uint(id))
+	if err != nil {
+		handleTrashError(c, "Failed to restore product", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Product restored successfully", product)
+}
+
+// @Summary Restore a category
+// @Description Restore a soft-deleted category, optionally with the products deleted along with it (Admin only)
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param cascade query bool false "Also restore the category's products"
+// @Success 200 {object} utils.Response{data=dto.CategoryResponse} "Category restored successfully"
+// @Failure 400 {object} utils.Response "Invalid category ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Category not in trash"
+// @Router /trash/categories/{id}/restore [post]
+func (s *Server) restoreCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	var req dto.RestoreCategoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	category, err := s.trashService.RestoreCategory(c.GetUint("user_id"), uint(id), req.Cascade)
+	if err != nil {
+		handleTrashError(c, "Failed to restore category", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Category restored successfully", category)
+}
+
+// @Summary Purge a product
+// @Description Permanently delete a trashed product. Fails while orders reference it (Admin only)
+// @Tags Trash
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response "Product purged successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not in trash"
+// @Failure 409 {object} utils.Response "Product is referenced by orders or is a component of a live bundle"
+// @Router /trash/products/{id} [delete]
+func (s *Server) purgeProduct(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	if err := s.trashService.PurgeProduct(uint(id)); err != nil {
+		handleTrashError(c, "Failed to purge product", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Product purged successfully", nil)
+}
+
+// @Summary Purge a category
+// @Description Permanently delete a trashed category and its trashed products. Fails while it has live products or orders reference its products (Admin only)
+// @Tags Trash
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.Response "Category purged successfully"
+// @Failure 400 {object} utils.Response "Category cannot be purged"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Category not in trash"
+// @Failure 409 {object} utils.Response "Category products are referenced by orders or live bundles"
+// @Router /trash/categories/{id} [delete]
+func (s *Server) purgeCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	if err := s.trashService.PurgeCategory(uint(id)); err != nil {
+		handleTrashError(c, "Failed to purge category", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Category purged successfully", nil)
+}
+
+func handleTrashError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotInTrash):
+		utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, services.ErrReferencedByOrder), errors.Is(err, services.ErrBundleComponent):
+		utils.ErrorResponse(c, http.StatusConflict, message, err)
+	default:
+		utils.BadRequestResponse(c, message, err)
+	}
+}
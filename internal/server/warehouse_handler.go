@@ -0,0 +1,298 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/services"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+// @Summary Get warehouses
+// @Description List the warehouses in the order they are tried when allocating orders (Admin only)
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.WarehouseResponse} "Warehouses retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /warehouses [get]
+func (s *Server) getWarehouses(c *gin.Context) {
+	warehouses, err := s.warehouseService.GetWarehouses()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch warehouses", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Warehouses retrieved successfully", warehouses)
+}
+
+// @Summary Create a warehouse
+// @Description Create a warehouse. Making it the default moves the default flag from the current default (Admin only)
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.WarehouseRequest true "Warehouse data"
+// @Success 201 {object} utils.Response{data=dto.WarehouseResponse} "Warehouse created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 409 {object} utils.Response "Warehouse code already in use"
+// @Router /warehouses [post]
+func (s *Server) createWarehouse(c *gin.Context) {
+	var req dto.WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	warehouse, err := s.warehouseService.CreateWarehouse(&req)
+	if err != nil {
+		handleWarehouseError(c, "Failed to create warehouse", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Warehouse created successfully", warehouse)
+}
+
+// @Summary Update a warehouse
+// @Description Update a warehouse's details, location and priority (Admin only)
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Param request body dto.WarehouseRequest true "Warehouse data"
+// @Success 200 {object} utils.Response{data=dto.WarehouseResponse} "Warehouse updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Warehouse not found"
+// @Failure 409 {object} utils.Response "Warehouse code already in use"
+// @Router /warehouses/{id} [put]
+func (s *Server) updateWarehouse(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid warehouse ID", err)
+		return
+	}
+
+	var req dto.WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	warehouse, err := s.warehouseService.UpdateWarehouse(uint(id), &req)
+	if err != nil {
+		handleWarehouseError(c, "Failed to update warehouse", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Warehouse updated successfully", warehouse)
+}
+
+// @Summary Delete a warehouse
+// @Description Delete an empty warehouse that has not shipped any orders (Admin only)
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} utils.Response "Warehouse deleted successfully"
+// @Failure 400 {object} utils.Response "Warehouse cannot be deleted"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Warehouse not found"
+// @Failure 409 {object} utils.Response "Warehouse still holds stock"
+// @Router /warehouses/{id} [delete]
+func (s *Server) deleteWarehouse(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid warehouse ID", err)
+		return
+	}
+
+	if err := s.warehouseService.DeleteWarehouse(uint(id)); err != nil {
+		handleWarehouseError(c, "Failed to delete warehouse", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Warehouse deleted successfully", nil)
+}
+
+// @Summary Get product stock by warehouse
+// @Description List a product's on-hand stock at every warehouse (Admin only)
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=[]dto.WarehouseStockResponse} "Stock retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /products/{id}/stock [get]
+func (s *Server) getProductStock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	stock, err := s.warehouseService.GetProductStock(uint(id))
+	if err != nil {
+		handleWarehouseError(c, "Failed to fetch stock", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Stock retrieved successfully", stock)
+}
+
+// @Summary Adjust product stock
+// @Description Add units to a product's stock at a warehouse, or remove them with a negative quantity. The change is recorded in the stock ledger with its reason: restock and return add units, damage removes them and adjustment does either (Admin only)
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.AdjustStockRequest true "Adjustment"
+// @Success 200 {object} utils.Response{data=[]dto.WarehouseStockResponse} "Stock adjusted successfully"
+// @Failure 400 {object} utils.Response "Invalid request data, a reason that disagrees with the quantity or insufficient stock"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product or warehouse not found"
+// @Router /products/{id}/stock/adjust [post]
+func (s *Server) adjustProductStock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	stock, err := s.warehouseService.AdjustStock(c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		handleWarehouseError(c, "Failed to adjust stock", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Stock adjusted successfully", stock)
+}
+
+// @Summary Transfer product stock
+// @Description Move units of a product from one warehouse to another. The ledger records the units leaving and arriving (Admin only)
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dto.TransferStockRequest true "Transfer"
+// @Success 200 {object} utils.Response{data=[]dto.WarehouseStockResponse} "Stock transferred successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product or warehouse not found"
+// @Router /products/{id}/stock/transfer [post]
+func (s *Server) transferProductStock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.TransferStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	stock, err := s.warehouseService.TransferStock(c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		handleWarehouseError(c, "Failed to transfer stock", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Stock transferred successfully", stock)
+}
+
+// @Summary Get stock movements
+// @Description Retrieve a product's stock ledger, newest first (Admin only)
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.StockMovementResponse} "Stock movements retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /products/{id}/stock/movements [get]
+func (s *Server) getStockMovements(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	movements, meta, err := s.warehouseService.GetStockMovements(uint(id), page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch stock movements", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Stock movements retrieved successfully", movements, *meta)
+}
+
+// @Summary Reconcile stock
+// @Description Compare every warehouse stock level and product total with the sum of its ledger movements and list the mismatches (Admin only)
+// @Tags Warehouses
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.StockReconciliationResponse} "Stock reconciled successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /inventory/reconciliation [get]
+func (s *Server) reconcileStock(c *gin.Context) {
+	report, err := s.warehouseService.ReconcileStock()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to reconcile stock", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Stock reconciled successfully", report)
+}
+
+func handleWarehouseError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrWarehouseNotFound):
+		utils.NotFoundResponse(c, "Warehouse not found")
+	case errors.Is(err, services.ErrWarehouseCodeInUse),
+		errors.Is(err, services.ErrWarehouseNotEmpty):
+		utils.ErrorResponse(c, http.StatusConflict, message, err)
+	case errors.Is(err, services.ErrInsufficientWarehouseStock),
+		errors.Is(err, services.ErrBundleStock),
+		errors.Is(err, services.ErrDefaultWarehouse),
+		errors.Is(err, services.ErrInvalidStockTransfer),
+		errors.Is(err, services.ErrWarehouseShippedOrders),
+		errors.Is(err, services.ErrInvalidStockReason),
+		errors.Is(err, services.ErrStockReasonSign):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fillBackorders allocates units of a product just received at a warehouse to
+// the order lines waiting for them, oldest first, recording the sales in the
+// ledger. It returns how many of the received units were used.
+func fillBackorders(tx *gorm.DB, productID, warehouseID uint, received int) (int, error) {
+	if received <= 0 {
+		return 0, nil
+	}
+
+	var items []models.OrderItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND backordered > 0", productID).
+		Order("id").
+		Find(&items).Error; err != nil {
+		return 0, err
+	}
+
+	filled := 0
+	for i := range items {
+		if filled == received {
+			break
+		}
+
+		quantity := min(items[i].Backordered, received-filled)
+		if err := addStock(tx, &models.StockMovement{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Quantity:    -quantity,
+			Reason:      models.StockMovementSale,
+			Reference:   fmt.Sprintf("order:%d", items[i].OrderID),
+		}); err != nil {
+			return 0, err
+		}
+
+		if err := tx.Create(&models.OrderItemAllocation{
+			OrderItemID: items[i].ID,
+			WarehouseID: warehouseID,
+			ProductID:   productID,
+			Quantity:    quantity,
+		}).Error; err != nil {
+			return 0, err
+		}
+
+		if err := tx.Model(&items[i]).
+			Update("backordered", gorm.Expr("backordered - ?", quantity)).Error; err != nil {
+			return 0, err
+		}
+
+		filled += quantity
+	}
+
+	if filled == 0 {
+		return 0, nil
+	}
+
+	if err := tx.Unscoped().Model(&models.Product{}).
+		Where("id = ?", productID).
+		Update("backordered", gorm.Expr("GREATEST(backordered - ?, 0)", filled)).Error; err != nil {
+		return 0, err
+	}
+
+	return filled, nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+// preloadProduct preloads everything convertToProductResponse needs for the
+// product found at path ("" for the root model, e.g. "CartItems.Product"),
+// including the translations of locale when it is not the default locale.
+func preloadProduct(db *gorm.DB, path, locale string) *gorm.DB {
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+
+	db = db.Preload(prefix+"Category").
+		Preload(prefix+"Images", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload(prefix + "Images.Renditions").
+		Preload(prefix + "BundleItems.Component")
+
+	if locale != "" && locale != models.DefaultLocale {
+		db = db.Preload(prefix+"Translations", "locale = ?", locale).
+			Preload(prefix+"Category.Translations", "locale = ?", locale).
+			Preload(prefix+"BundleItems.Component.Translations", "locale = ?", locale)
+	}
+
+	return db
+}
@@ -2,135 +2,262 @@ package services
 
 import (
 	"errors"
+	"time"
 
+	"github.com/joefazee/learning-go-shop/internal/config"
 	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
 	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/money"
 	"gorm.io/gorm"
 )
 
 var _ CartServiceInterface = (*CartService)(nil)
 
 type CartService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	config *config.Config
+	media  interfaces.UploadProvider
 }
 
-func NewCartService(db *gorm.DB) *CartService {
-	return &CartService{db: db}
+func NewCartService(db *gorm.DB, config *config.Config, media interfaces.UploadProvider) *CartService {
+	return &CartService{db: db, config: config, media: media}
 }
 
-func (s *CartService) GetCart(userID uint) (*dto.CartResponse, error) {
+// GetCart returns the user's cart presented in the given currency and locale
+func (s *CartService) GetCart(userID uint, l10n dto.Localization) (*dto.CartResponse, error) {
 	var cart models.Cart
-	err := s.db.Preload("CartItems.Product.Category").
+	err := preloadProduct(s.db, "CartItems.Product", l10n.Locale).
 		Where("user_id = ?", userID).First(&cart).Error
 	if err != nil {
 		return nil, err
 	}
 
-	return s.convertToCartResponse(&cart), nil
-}
+	products := make([]*models.Product, len(cart.CartItems))
+	for i := range cart.CartItems {
+		products[i] = &cart.CartItems[i].Product
+	}
+
+	prices, err := loadPriceList(s.db, s.config.Store.BaseCurrency, l10n.Currency, products...)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *CartService) AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.CartResponse, error) {
+	response := s.convertToCartResponse(&cart, prices)
 
-	// Check if product exists
-	var product models.Product
-	if err := s.db.First(&product, req.ProductID).Error; err != nil {
-		return nil, errors.New("product not found")
+	var reservation models.StockReservation
+	if err := s.db.Where("user_id = ?", userID).Order("expires_at").Limit(1).Find(&reservation).Error; err != nil {
+		return nil, err
 	}
+	if reservation.ID != 0 {
+		response.ReservedUntil = &reservation.ExpiresAt
+	}
+
+	return response, nil
+}
+
+func (s *CartService) AddToCart(userID uint, req *dto.AddToCartRequest, l10n dto.Localization) (*dto.CartResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Check if product exists
+		var product models.Product
+		if err := tx.Preload("BundleItems.Component").First(&product, req.ProductID).Error; err != nil {
+			return errors.New("product not found")
+		}
+
+		held, err := heldStock(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !hasStock(&product, req.Quantity, held) {
+			return ErrInsufficientStock
+		}
+
+		// Get or create cart
+		var cart models.Cart
+		if err := tx.Where("user_id = ?", userID).First(&cart).Error; err != nil {
+			cart = models.Cart{UserID: userID}
+			if err := tx.Create(&cart).Error; err != nil {
+				return err
+			}
+		}
+
+		// Check if item already exists in cart
+		var cartItem models.CartItem
+		if err := tx.Where("cart_id = ? AND product_id = ?", cart.ID, req.ProductID).First(&cartItem).Error; err != nil {
+			// Create new cart item
+			cartItem = models.CartItem{
+				CartID:    cart.ID,
+				ProductID: req.ProductID,
+				Quantity:  req.Quantity,
+			}
+			if err := tx.Create(&cartItem).Error; err != nil {
+				return err
+			}
+		} else {
+			// Update existing cart item
+			cartItem.Quantity += req.Quantity
+			if !hasStock(&product, cartItem.Quantity, held) {
+				return ErrInsufficientStock
+			}
+			if err := tx.Save(&cartItem).Error; err != nil {
+				return err
+			}
+		}
+
+		return s.syncReservation(tx, userID)
+	})
 
-	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient stock")
+	if err != nil {
+		return nil, err
 	}
 
-	// Get or create cart
-	var cart models.Cart
-	if err := s.db.Where("user_id = ?", userID).First(&cart).Error; err != nil {
-		cart = models.Cart{UserID: userID}
-		if err := s.db.Create(&cart).Error; err != nil {
-			return nil, err
+	return s.GetCart(userID, l10n)
+}
+
+func (s *CartService) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest, l10n dto.Localization) (*dto.CartResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var cartItem models.CartItem
+		if err := tx.Joins("JOIN carts ON cart_items.cart_id = carts.id").
+			Where("cart_items.id = ? AND carts.user_id = ?", itemID, userID).
+			First(&cartItem).Error; err != nil {
+			return errors.New("cart item not found")
+		}
+
+		var product models.Product
+		if err := tx.Preload("BundleItems.Component").First(&product, cartItem.ProductID).Error; err != nil {
+			return errors.New("product not found")
+		}
+
+		held, err := heldStock(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !hasStock(&product, req.Quantity, held) {
+			return ErrInsufficientStock
+		}
+
+		cartItem.Quantity = req.Quantity
+		if err := tx.Save(&cartItem).Error; err != nil {
+			return err
 		}
+
+		return s.syncReservation(tx, userID)
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if item already exists in cart
-	var cartItem models.CartItem
-	if err := s.db.Where("cart_id = ? AND product_id = ?", cart.ID, req.ProductID).First(&cartItem).Error; err != nil {
-		// Create new cart item
-		cartItem = models.CartItem{
-			CartID:    cart.ID,
-			ProductID: req.ProductID,
-			Quantity:  req.Quantity,
+	return s.GetCart(userID, l10n)
+}
+
+func (s *CartService) RemoveFromCart(userID, itemID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND cart_id IN (?)", itemID,
+			tx.Select("id").Table("carts").
+				Where("user_id = ?", userID)).
+			Delete(&models.CartItem{}).Error; err != nil {
+			return err
+		}
+
+		return s.syncReservation(tx, userID)
+	})
+}
+
+// ReserveCart holds the stock of the user's cart for the configured TTL as
+// checkout begins. Reserving again extends the hold and picks up cart changes.
+func (s *CartService) ReserveCart(userID uint, l10n dto.Localization) (*dto.CartResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		required, err := cartRequirements(tx, userID)
+		if err != nil {
+			return err
 		}
-		s.db.Create(&cartItem)
-	} else {
-		// Update existing cart item
-		cartItem.Quantity += req.Quantity
-		if cartItem.Quantity > product.Stock {
-			return nil, errors.New("insufficient stock")
+
+		if len(required) == 0 {
+			return errors.New("cart has no items to reserve")
 		}
-		s.db.Save(&cartItem)
+
+		return holdStock(tx, userID, required, time.Now().Add(s.config.Stock.ReservationTTL))
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return s.GetCart(userID)
+	return s.GetCart(userID, l10n)
+}
+
+// ReleaseCart gives back the stock held for the user's checkout
+func (s *CartService) ReleaseCart(userID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return releaseStock(tx, userID)
+	})
+}
+
+// ReleaseExpiredReservations releases reservations that expired before the
+// given time and returns how many were released
+func (s *CartService) ReleaseExpiredReservations(before time.Time) (int, error) {
+	return releaseExpiredReservations(s.db, before)
 }
 
-func (s *CartService) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
-	var cartItem models.CartItem
-	if err := s.db.Joins("JOIN carts ON cart_items.cart_id = carts.id").
-		Where("cart_items.id = ? AND carts.user_id = ?", itemID, userID).
-		First(&cartItem).Error; err != nil {
-		return nil, errors.New("cart item not found")
+// syncReservation keeps the reservation in line with the cart when stock is
+// reserved on add to cart
+func (s *CartService) syncReservation(tx *gorm.DB, userID uint) error {
+	if !s.config.Stock.ReserveOnAddToCart {
+		return nil
 	}
 
-	var product models.Product
-	if err := s.db.First(&product, cartItem.ProductID).Error; err != nil {
-		return nil, errors.New("product not found")
+	required, err := cartRequirements(tx, userID)
+	if err != nil {
+		return err
 	}
 
-	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient stock")
+	return holdStock(tx, userID, required, time.Now().Add(s.config.Stock.ReservationTTL))
+}
+
+// cartRequirements returns the units of each product the user's cart needs
+// from stock. Units that will be backordered are not held.
+func cartRequirements(tx *gorm.DB, userID uint) (map[uint]int, error) {
+	var cartItems []models.CartItem
+	if err := tx.Preload("Product.BundleItems.Component").
+		Joins("JOIN carts ON cart_items.cart_id = carts.id").
+		Where("carts.user_id = ?", userID).
+		Find(&cartItems).Error; err != nil {
+		return nil, err
 	}
 
-	cartItem.Quantity = req.Quantity
-	if err := s.db.Save(&cartItem).Error; err != nil {
+	held, err := heldStock(tx, userID)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.GetCart(userID)
-}
+	required := make(map[uint]int)
+	for i := range cartItems {
+		product := &cartItems[i].Product
+		inStock := inStockQuantity(product, cartItems[i].Quantity, held)
+		for productID, quantity := range stockRequirements(product, inStock) {
+			required[productID] += quantity
+		}
+	}
 
-func (s *CartService) RemoveFromCart(userID, itemID uint) error {
-	return s.db.Where("id = ? AND cart_id IN (?)", itemID,
-		s.db.Select("id").Table("carts").
-			Where("user_id = ?", userID)).
-		Delete(&models.CartItem{}).Error
+	return required, nil
 }
 
-func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse {
+func (s *CartService) convertToCartResponse(cart *models.Cart, prices *priceList) *dto.CartResponse {
 
 	cartItems := make([]dto.CartItemResponse, len(cart.CartItems)) // memory allocation
-	var total float64
+	var total money.Money
 
 	for i := range cart.CartItems {
-		subtotal := float64(cart.CartItems[i].Quantity) * cart.CartItems[i].Product.Price
+		product := &cart.CartItems[i].Product
+		subtotal := prices.price(product).Times(cart.CartItems[i].Quantity)
 		total += subtotal
 
 		cartItems[i] = dto.CartItemResponse{
-			ID: cart.CartItems[i].ID,
-			Product: dto.ProductResponse{
-				ID:          cart.CartItems[i].Product.ID,
-				CategoryID:  cart.CartItems[i].Product.CategoryID,
-				Name:        cart.CartItems[i].Product.Name,
-				Description: cart.CartItems[i].Product.Description,
-				Price:       cart.CartItems[i].Product.Price,
-				Stock:       cart.CartItems[i].Product.Stock,
-				SKU:         cart.CartItems[i].Product.SKU,
-				IsActive:    cart.CartItems[i].Product.IsActive,
-				Category: dto.CategoryResponse{
-					ID:          cart.CartItems[i].Product.Category.ID,
-					Name:        cart.CartItems[i].Product.Category.Name,
-					Description: cart.CartItems[i].Product.Category.Description,
-					IsActive:    cart.CartItems[i].Product.Category.IsActive,
-				},
-			},
+			ID:        cart.CartItems[i].ID,
+			Product:   convertToProductResponse(product, prices, s.media),
 			Quantity:  cart.CartItems[i].Quantity,
 			Subtotal:  subtotal,
 			CreatedAt: cart.CartItems[i].CreatedAt,
@@ -143,6 +270,7 @@ func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse
 		UserID:    cart.UserID,
 		CartItems: cartItems,
 		Total:     total,
+		Currency:  prices.currency,
 		CreatedAt: cart.CreatedAt,
 		UpdatedAt: cart.UpdatedAt,
 	}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/money"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCurrency     = errors.New("currency must be a three letter ISO 4217 code")
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+)
+
+// normalizeCurrency upper-cases a currency code and checks that it looks like
+// an ISO 4217 code. An empty code stays empty and means the base currency.
+func normalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return "", nil
+	}
+
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+
+	return code, nil
+}
+
+// priceList prices products in a single currency. Products with an entry in
+// the currency's price list use it, everything else is converted from the
+// base price at rate.
+type priceList struct {
+	currency string
+	rate     float64
+	prices   map[uint]money.Money
+}
+
+// loadPriceList returns the price list for currency, loading the entries of
+// the given products and their bundle components. An empty currency selects
+// the base currency.
+func loadPriceList(db *gorm.DB, baseCurrency, currency string, products ...*models.Product) (*priceList, error) {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == "" || currency == baseCurrency {
+		return &priceList{currency: baseCurrency, rate: 1}, nil
+	}
+
+	var rate models.ExchangeRate
+	if err := db.Where("currency = ?", currency).First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUnsupportedCurrency
+		}
+		return nil, err
+	}
+
+	return newPriceList(db, currency, rate.Rate, products...)
+}
+
+// newPriceList builds a price list at a fixed rate, e.g. the rate an order was
+// placed at.
+func newPriceList(db *gorm.DB, currency string, rate float64, products ...*models.Product) (*priceList, error) {
+	list := &priceList{currency: currency, rate: rate, prices: make(map[uint]money.Money)}
+
+	var productIDs []uint
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+		for i := range product.BundleItems {
+			productIDs = append(productIDs, product.BundleItems[i].ComponentID)
+		}
+	}
+
+	if len(productIDs) == 0 {
+		return list, nil
+	}
+
+	var entries []models.ProductPrice
+	if err := db.Where("currency = ? AND product_id IN ?", currency, productIDs).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		list.prices[entry.ProductID] = entry.Price
+	}
+
+	return list, nil
+}
+
+// price returns the selling price of a product in the list's currency.
+// Percentage priced bundles without a price list entry are derived from the
+// component prices in the same currency, so BundleItems.Component must be
+// loaded.
+func (l *priceList) price(product *models.Product) money.Money {
+	if price, ok := l.prices[product.ID]; ok {
+		return price
+	}
+
+	if !product.IsBundle || product.BundlePricing != models.BundlePricingPercentage {
+		return product.Price.Convert(l.rate)
+	}
+
+	var componentTotal money.Money
+	for i := range product.BundleItems {
+		item := &product.BundleItems[i]
+		componentTotal += l.price(&item.Component).Times(item.Quantity)
+	}
+
+	return componentTotal.Percent(product.BundlePercentage)
+}
+
+// toBase converts an amount in the list's currency to the base currency
+func (l *priceList) toBase(amount money.Money) money.Money {
+	return amount.ConvertBack(l.rate)
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrBaseCurrency         = errors.New("the base currency has no exchange rate or price list")
+	ErrProductPriceNotFound = errors.New("product price not found")
+)
+
+var _ CurrencyServiceInterface = (*CurrencyService)(nil)
+
+type CurrencyService struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+func NewCurrencyService(db *gorm.DB, config *config.Config) *CurrencyService {
+	return &CurrencyService{db: db, config: config}
+}
+
+// ResolveCurrency normalizes a requested currency code. An empty code resolves
+// to the base currency; any other currency needs an exchange rate.
+func (s *CurrencyService) ResolveCurrency(code string) (string, error) {
+	code, err := normalizeCurrency(code)
+	if err != nil {
+		return "", err
+	}
+
+	if code == "" || code == s.config.Store.BaseCurrency {
+		return s.config.Store.BaseCurrency, nil
+	}
+
+	var count int64
+	if err := s.db.Model(&models.ExchangeRate{}).Where("currency = ?", code).Count(&count).Error; err != nil {
+		return "", err
+	}
+
+	if count == 0 {
+		return "", ErrUnsupportedCurrency
+	}
+
+	return code, nil
+}
+
+func (s *CurrencyService) GetCurrencies() ([]dto.CurrencyResponse, error) {
+	var rates []models.ExchangeRate
+	if err := s.db.Order("currency").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CurrencyResponse, 0, len(rates)+1)
+	response = append(response, dto.CurrencyResponse{
+		Code:   s.config.Store.BaseCurrency,
+		Rate:   1,
+		IsBase: true,
+	})
+
+	for i := range rates {
+		response = append(response, convertToCurrencyResponse(&rates[i]))
+	}
+
+	return response, nil
+}
+
+func (s *CurrencyService) SetExchangeRate(currency string, req *dto.SetExchangeRateRequest) (*dto.CurrencyResponse, error) {
+	currency, err := s.foreignCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := models.ExchangeRate{
+		Currency: currency,
+		Rate:     req.Rate,
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Where("currency = ?", currency).First(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToCurrencyResponse(&rate)
+	return &response, nil
+}
+
+// DeleteExchangeRate stops supporting a currency and drops its price list.
+// Orders already placed in it keep their stored currency and rate.
+func (s *CurrencyService) DeleteExchangeRate(currency string) error {
+	currency, err := s.foreignCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("currency = ?", currency).Delete(&models.ExchangeRate{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrUnsupportedCurrency
+		}
+
+		return tx.Where("currency = ?", currency).Delete(&models.ProductPrice{}).Error
+	})
+}
+
+func (s *CurrencyService) GetProductPrices(productID uint) ([]dto.ProductPriceResponse, error) {
+	var prices []models.ProductPrice
+	if err := s.db.Where("product_id = ?", productID).Order("currency").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ProductPriceResponse, len(prices))
+	for i := range prices {
+		response[i] = convertToProductPriceResponse(&prices[i])
+	}
+
+	return response, nil
+}
+
+// SetProductPrice sets the price list entry of a product in a supported
+// currency, replacing the converted base price
+func (s *CurrencyService) SetProductPrice(productID uint, currency string, req *dto.SetProductPriceRequest) (*dto.ProductPriceResponse, error) {
+	currency, err := s.foreignCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ResolveCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	price := models.ProductPrice{
+		ProductID: productID,
+		Currency:  currency,
+		Price:     req.Price,
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "updated_at"}),
+	}).Create(&price).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Where("product_id = ? AND currency = ?", productID, currency).First(&price).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToProductPriceResponse(&price)
+	return &response, nil
+}
+
+func (s *CurrencyService) DeleteProductPrice(productID uint, currency string) error {
+	currency, err := s.foreignCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Where("product_id = ? AND currency = ?", productID, currency).Delete(&models.ProductPrice{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrProductPriceNotFound
+	}
+
+	return nil
+}
+
+// foreignCurrency normalizes a currency code that must not be the base currency
+func (s *CurrencyService) foreignCurrency(code string) (string, error) {
+	code, err := normalizeCurrency(code)
+	if err != nil {
+		return "", err
+	}
+
+	if code == "" {
+		return "", ErrInvalidCurrency
+	}
+
+	if code == s.config.Store.BaseCurrency {
+		return "", ErrBaseCurrency
+	}
+
+	return code, nil
+}
+
+func convertToCurrencyResponse(rate *models.ExchangeRate) dto.CurrencyResponse {
+	return dto.CurrencyResponse{
+		Code:      rate.Currency,
+		Rate:      rate.Rate,
+		UpdatedAt: &rate.UpdatedAt,
+	}
+}
+
+func convertToProductPriceResponse(price *models.ProductPrice) dto.ProductPriceResponse {
+	return dto.ProductPriceResponse{
+		ProductID: price.ProductID,
+		Currency:  price.Currency,
+		Price:     price.Price,
+		UpdatedAt: price.UpdatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidDownloadLink  = errors.New("download link is invalid or has expired")
+	ErrDownloadLimitReached = errors.New("download limit reached")
+)
+
+var _ DownloadServiceInterface = (*DownloadService)(nil)
+
+// DownloadFile is an opened digital product file ready to be streamed to the buyer
+type DownloadFile struct {
+	Reader      io.ReadCloser
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+type DownloadService struct {
+	db       *gorm.DB
+	config   *config.Config
+	provider interfaces.UploadProvider
+}
+
+// NewDownloadService creates the download service. provider must be the
+// private storage the digital product files were uploaded to.
+func NewDownloadService(db *gorm.DB, config *config.Config, provider interfaces.UploadProvider) *DownloadService {
+	return &DownloadService{
+		db:       db,
+		config:   config,
+		provider: provider,
+	}
+}
+
+func (s *DownloadService) GetOrderDownloads(userID, orderID uint) ([]dto.DownloadLinkResponse, error) {
+	var order models.Order
+	if err := s.db.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		return nil, err
+	}
+
+	if order.Status == models.OrderStatusCancelled {
+		return nil, errors.New("order has been cancelled")
+	}
+
+	var grants []models.DownloadGrant
+	if err := s.db.Preload("ProductFile.Product").
+		Where("order_id = ?", order.ID).
+		Order("id").
+		Find(&grants).Error; err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.config.Download.LinkTTL).Truncate(time.Second)
+	links := make([]dto.DownloadLinkResponse, len(grants))
+	for i := range grants {
+		grant := &grants[i]
+		links[i] = dto.DownloadLinkResponse{
+			ProductID:          grant.ProductFile.ProductID,
+			ProductName:        grant.ProductFile.Product.Name,
+			FileName:           grant.ProductFile.FileName,
+			Size:               grant.ProductFile.Size,
+			URL:                s.signedURL(grant.ID, expiresAt),
+			ExpiresAt:          expiresAt,
+			DownloadsRemaining: max(grant.MaxDownloads-grant.DownloadCount, 0),
+		}
+	}
+
+	return links, nil
+}
+
+func (s *DownloadService) OpenDownload(grantID uint, expires int64, signature string) (*DownloadFile, error) {
+	if time.Now().Unix() > expires ||
+		!utils.VerifySignature(s.config.Download.SigningSecret, downloadSignatureValue(grantID, expires), signature) {
+		return nil, ErrInvalidDownloadLink
+	}
+
+	var grant models.DownloadGrant
+	if err := s.db.Preload("ProductFile").First(&grant, grantID).Error; err != nil {
+		return nil, ErrInvalidDownloadLink
+	}
+
+	if grant.DownloadCount >= grant.MaxDownloads {
+		return nil, ErrDownloadLimitReached
+	}
+
+	// The file is opened before the download is counted, so a storage
+	// failure doesn't use up one of the buyer's downloads
+	reader, err := s.provider.Open(grant.ProductFile.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file: %w", err)
+	}
+
+	// Count the download atomically so parallel requests cannot exceed the limit
+	result := s.db.Model(&models.DownloadGrant{}).
+		Where("id = ? AND download_count < max_downloads", grant.ID).
+		Update("download_count", gorm.Expr("download_count + 1"))
+	if result.Error != nil {
+		reader.Close()
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		reader.Close()
+		return nil, ErrDownloadLimitReached
+	}
+
+	return &DownloadFile{
+		Reader:      reader,
+		FileName:    grant.ProductFile.FileName,
+		ContentType: grant.ProductFile.ContentType,
+		Size:        grant.ProductFile.Size,
+	}, nil
+}
+
+// ReleaseDownload gives back a download counted by OpenDownload when its file
+// could not be sent in full
+func (s *DownloadService) ReleaseDownload(grantID uint) error {
+	return s.db.Model(&models.DownloadGrant{}).
+		Where("id = ? AND download_count > 0", grantID).
+		Update("download_count", gorm.Expr("download_count - 1")).Error
+}
+
+func (s *DownloadService) signedURL(grantID uint, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	signature := utils.SignValue(s.config.Download.SigningSecret, downloadSignatureValue(grantID, expires))
+
+	return fmt.Sprintf("%s/api/v1/downloads/%d?expires=%d&signature=%s",
+		strings.TrimSuffix(s.config.Server.PublicURL, "/"), grantID, expires, signature)
+}
+
+func downloadSignatureValue(grantID uint, expires int64) string {
+	return fmt.Sprintf("download:%d:%d", grantID, expires)
+}
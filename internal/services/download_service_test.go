@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/providers"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+)
+
+func TestOpenDownload(t *testing.T) {
+	const secret = "secret"
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "digital"), 0o755); err != nil {
+		t.Fatalf("os.MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "digital", "book.pdf"), []byte("book"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Download.SigningSecret = secret
+
+	expires := time.Now().Add(time.Minute).Unix()
+	signature := utils.SignValue(secret, downloadSignatureValue(1, expires))
+
+	expectGrant := func(mock sqlmock.Sqlmock, key string, count int) {
+		mock.ExpectQuery(`SELECT \* FROM "download_grants"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_file_id", "download_count", "max_downloads"}).
+				AddRow(1, 1, count, 3))
+		mock.ExpectQuery(`SELECT \* FROM "product_files"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "storage_key", "file_name", "size"}).
+				AddRow(1, key, "book.pdf", 4))
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		count   int
+		update  bool
+		updated int64
+		wantErr error
+	}{
+		{name: "download", key: "digital/book.pdf", update: true, updated: 1},
+		{name: "file missing from storage", key: "digital/missing.pdf"},
+		{name: "limit reached", key: "digital/book.pdf", count: 3, wantErr: ErrDownloadLimitReached},
+		{name: "limit reached by a parallel download", key: "digital/book.pdf", update: true, wantErr: ErrDownloadLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			s := NewDownloadService(db, cfg, providers.NewLocalUploadProvider(dir, ""))
+
+			expectGrant(mock, tt.key, tt.count)
+			if tt.update {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "download_grants" SET "download_count"=download_count \+ 1`).
+					WillReturnResult(sqlmock.NewResult(0, tt.updated))
+				mock.ExpectCommit()
+			}
+
+			file, err := s.OpenDownload(1, expires, signature)
+			if tt.updated == 0 {
+				if err == nil {
+					file.Reader.Close()
+					t.Fatal("OpenDownload() error = nil, want an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("OpenDownload() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenDownload() error = %v", err)
+			}
+			defer file.Reader.Close()
+
+			data, err := io.ReadAll(file.Reader)
+			if err != nil || string(data) != "book" {
+				t.Errorf("OpenDownload() file = %q, %v, want %q", data, err, "book")
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"io"
 	"mime/multipart"
+	"time"
 
 	"github.com/joefazee/learning-go-shop/internal/dto"
 	"github.com/joefazee/learning-go-shop/internal/utils"
@@ -20,34 +22,121 @@ type UserServiceInterface interface {
 }
 
 type ProductServiceInterface interface {
-	CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
-	GetCategories() ([]dto.CategoryResponse, error)
-	UpdateCategory(id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
-	DeleteCategory(id uint) error
+	CreateCategory(actorID uint, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	GetCategories(locale string) ([]dto.CategoryResponse, error)
+	UpdateCategory(actorID, id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
+	DeleteCategory(actorID, id uint) error
 
-	CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error)
-	GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error)
-	GetProduct(id uint) (*dto.ProductResponse, error)
-	UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
-	DeleteProduct(id uint) error
+	CreateProduct(actorID uint, req *dto.CreateProductRequest) (*dto.ProductResponse, error)
+	GetProducts(req *dto.ListProductsRequest) ([]dto.ProductResponse, *utils.PaginationMeta, error)
+	GetProduct(id uint, l10n dto.Localization) (*dto.ProductResponse, error)
+	UpdateProduct(actorID, id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
+	DeleteProduct(actorID, id uint) error
+	SetBundle(actorID, productID uint, req *dto.SetBundleRequest) (*dto.ProductResponse, error)
+	RemoveBundle(actorID, productID uint) (*dto.ProductResponse, error)
 
-	AddProductImage(productID uint, url, altText string) error
-	SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error)
+	AddProductImage(productID uint, image *UploadedImage, altText string) (*dto.ProductImageResponse, error)
+	UpdateProductImage(productID, imageID uint, req *dto.UpdateProductImageRequest) (*dto.ProductImageResponse, error)
+	DeleteProductImage(productID, imageID uint) ([]string, error)
+	ReorderProductImages(productID uint, imageIDs []uint) ([]dto.ProductImageResponse, error)
+	SetPrimaryProductImage(productID, imageID uint) ([]dto.ProductImageResponse, error)
+	AddProductFile(productID uint, storageKey, fileName, contentType string, size int64) (*dto.ProductFileResponse, error)
+	GetProductFiles(productID uint) ([]dto.ProductFileResponse, error)
+	SearchProducts(req *dto.SearchProductsRequest) (results []dto.ProductSearchResult, meta *utils.PaginationMeta, didYouMean string, err error)
+	SuggestSearch(req *dto.SearchSuggestRequest) (*dto.SearchSuggestionsResponse, error)
+	RefreshSearchVocabulary() error
+}
+
+type TrashServiceInterface interface {
+	GetTrash(req *dto.ListTrashRequest) ([]dto.TrashItemResponse, *utils.PaginationMeta, error)
+	RestoreProduct(actorID, id uint) (*dto.ProductResponse, error)
+	RestoreCategory(actorID, id uint, cascade bool) (*dto.CategoryResponse, error)
+	PurgeProduct(id uint) error
+	PurgeCategory(id uint) error
+	PurgeExpired(cutoff time.Time) (*PurgeSummary, error)
+}
+
+type RevisionServiceInterface interface {
+	GetRevisions(entityType string, entityID uint, page, limit int) ([]dto.RevisionResponse, *utils.PaginationMeta, error)
+	GetRevision(id uint) (*dto.RevisionResponse, error)
+	Rollback(actorID, revisionID uint) (*dto.RevisionResponse, error)
+}
+
+type CurrencyServiceInterface interface {
+	ResolveCurrency(code string) (string, error)
+	GetCurrencies() ([]dto.CurrencyResponse, error)
+	SetExchangeRate(currency string, req *dto.SetExchangeRateRequest) (*dto.CurrencyResponse, error)
+	DeleteExchangeRate(currency string) error
+	GetProductPrices(productID uint) ([]dto.ProductPriceResponse, error)
+	SetProductPrice(productID uint, currency string, req *dto.SetProductPriceRequest) (*dto.ProductPriceResponse, error)
+	DeleteProductPrice(productID uint, currency string) error
+}
+
+type TranslationServiceInterface interface {
+	ResolveLocale(locale string) (string, error)
+	GetProductTranslations(productID uint) ([]dto.TranslationResponse, error)
+	SetProductTranslation(productID uint, locale string, req *dto.SetTranslationRequest) (*dto.TranslationResponse, error)
+	DeleteProductTranslation(productID uint, locale string) error
+	GetCategoryTranslations(categoryID uint) ([]dto.TranslationResponse, error)
+	SetCategoryTranslation(categoryID uint, locale string, req *dto.SetTranslationRequest) (*dto.TranslationResponse, error)
+	DeleteCategoryTranslation(categoryID uint, locale string) error
+}
+
+type ReviewServiceInterface interface {
+	CreateReview(userID, productID uint, req *dto.CreateReviewRequest) (*dto.ReviewResponse, error)
+	GetProductReviews(productID uint, req *dto.ListReviewsRequest) ([]dto.ReviewResponse, *utils.PaginationMeta, error)
+	GetReviewsByStatus(status string, page, limit int) ([]dto.ReviewResponse, *utils.PaginationMeta, error)
+	ModerateReview(reviewID uint, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error)
+	MarkHelpful(userID, reviewID uint) (*dto.ReviewResponse, error)
 }
 
 type CartServiceInterface interface {
-	GetCart(userID uint) (*dto.CartResponse, error)
-	AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.CartResponse, error)
-	UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error)
+	GetCart(userID uint, l10n dto.Localization) (*dto.CartResponse, error)
+	AddToCart(userID uint, req *dto.AddToCartRequest, l10n dto.Localization) (*dto.CartResponse, error)
+	UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest, l10n dto.Localization) (*dto.CartResponse, error)
 	RemoveFromCart(userID, itemID uint) error
+	ReserveCart(userID uint, l10n dto.Localization) (*dto.CartResponse, error)
+	ReleaseCart(userID uint) error
+	ReleaseExpiredReservations(before time.Time) (int, error)
 }
 
 type OrderServiceInterface interface {
-	CreateOrder(userID uint) (*dto.OrderResponse, error)
-	GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error)
-	GetOrder(userID, orderID uint) (*dto.OrderResponse, error)
+	CreateOrder(userID uint, req *dto.CreateOrderRequest, l10n dto.Localization) (*dto.OrderResponse, error)
+	GetOrders(userID uint, page, limit int, locale string) ([]dto.OrderResponse, *utils.PaginationMeta, error)
+	GetOrder(userID, orderID uint, locale string) (*dto.OrderResponse, error)
+}
+
+type DownloadServiceInterface interface {
+	GetOrderDownloads(userID, orderID uint) ([]dto.DownloadLinkResponse, error)
+	OpenDownload(grantID uint, expires int64, signature string) (*DownloadFile, error)
+	ReleaseDownload(grantID uint) error
 }
 
 type UploadServiceInterface interface {
-	UploadProductImage(productID uint, file *multipart.FileHeader) (string, error)
+	UploadProductImage(productID uint, file *multipart.FileHeader) (*UploadedImage, error)
+	UploadProductImageFrom(productID uint, filename string, size int64, src io.Reader) (*UploadedImage, error)
+	UploadProductFile(productID uint, file *multipart.FileHeader) (string, error)
+	ImportProductImage(productID uint, rawURL string) (*UploadedImage, error)
+	PresignProductImage(productID uint, req *dto.PresignImageUploadRequest) (*dto.PresignedUploadResponse, error)
+	ConfirmProductImage(productID, uploadID uint) (*UploadedImage, error)
+	DeleteFiles(paths []string)
+	CollectOrphanedUploads(cutoff time.Time, dryRun bool) (*UploadGCReport, error)
+}
+
+type StockSubscriptionServiceInterface interface {
+	Subscribe(userID, productID uint) (*dto.StockSubscriptionResponse, error)
+	GetSubscriptions(userID uint) ([]dto.StockSubscriptionResponse, error)
+	CancelSubscription(userID, id uint) error
+}
+
+type WarehouseServiceInterface interface {
+	GetWarehouses() ([]dto.WarehouseResponse, error)
+	CreateWarehouse(req *dto.WarehouseRequest) (*dto.WarehouseResponse, error)
+	UpdateWarehouse(id uint, req *dto.WarehouseRequest) (*dto.WarehouseResponse, error)
+	DeleteWarehouse(id uint) error
+	GetProductStock(productID uint) ([]dto.WarehouseStockResponse, error)
+	AdjustStock(actorID, productID uint, req *dto.AdjustStockRequest) ([]dto.WarehouseStockResponse, error)
+	TransferStock(actorID, productID uint, req *dto.TransferStockRequest) ([]dto.WarehouseStockResponse, error)
+	GetStockMovements(productID uint, page, limit int) ([]dto.StockMovementResponse, *utils.PaginationMeta, error)
+	ReconcileStock() (*dto.StockReconciliationResponse, error)
 }
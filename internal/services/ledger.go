@@ -0,0 +1,160 @@
+package services
+
+import (
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+// stockBalance is a stock level next to the sum of its ledger movements
+type stockBalance struct {
+	ProductID      uint
+	WarehouseID    *uint
+	LedgerQuantity int
+	StockQuantity  int
+}
+
+// reconcileStock compares every warehouse stock level and product total with
+// the stock ledger
+func reconcileStock(db *gorm.DB) (*dto.StockReconciliationResponse, error) {
+	var balances []stockBalance
+	if err := db.Raw(`
+		SELECT COALESCE(ws.product_id, m.product_id) AS product_id,
+			COALESCE(ws.warehouse_id, m.warehouse_id) AS warehouse_id,
+			COALESCE(m.total, 0) AS ledger_quantity,
+			COALESCE(ws.quantity, 0) AS stock_quantity
+		FROM warehouse_stocks ws
+		FULL OUTER JOIN (
+			SELECT product_id, warehouse_id, SUM(quantity) AS total
+			FROM stock_movements
+			GROUP BY product_id, warehouse_id
+		) m ON m.product_id = ws.product_id AND m.warehouse_id = ws.warehouse_id`).
+		Scan(&balances).Error; err != nil {
+		return nil, err
+	}
+
+	var totals []stockBalance
+	if err := db.Raw(`
+		SELECT p.id AS product_id,
+			COALESCE(m.total, 0) AS ledger_quantity,
+			p.stock AS stock_quantity
+		FROM products p
+		LEFT JOIN (
+			SELECT product_id, SUM(quantity) AS total
+			FROM stock_movements
+			GROUP BY product_id
+		) m ON m.product_id = p.id`).
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	response := &dto.StockReconciliationResponse{
+		CheckedAt:     time.Now(),
+		Checked:       len(balances) + len(totals),
+		Discrepancies: []dto.StockDiscrepancy{},
+	}
+
+	var mismatched []stockBalance
+	productIDs := make(map[uint]bool)
+	warehouseIDs := make(map[uint]bool)
+	for _, balance := range append(balances, totals...) {
+		if balance.LedgerQuantity == balance.StockQuantity {
+			continue
+		}
+
+		mismatched = append(mismatched, balance)
+		productIDs[balance.ProductID] = true
+		if balance.WarehouseID != nil {
+			warehouseIDs[*balance.WarehouseID] = true
+		}
+	}
+
+	if len(mismatched) == 0 {
+		return response, nil
+	}
+
+	var products []models.Product
+	if err := db.Unscoped().Where("id IN ?", mapKeys(productIDs)).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	productsByID := make(map[uint]*models.Product, len(products))
+	for i := range products {
+		productsByID[products[i].ID] = &products[i]
+	}
+
+	warehouseCodes := make(map[uint]string)
+	if len(warehouseIDs) > 0 {
+		var warehouses []models.Warehouse
+		if err := db.Where("id IN ?", mapKeys(warehouseIDs)).Find(&warehouses).Error; err != nil {
+			return nil, err
+		}
+
+		for i := range warehouses {
+			warehouseCodes[warehouses[i].ID] = warehouses[i].Code
+		}
+	}
+
+	for _, balance := range mismatched {
+		discrepancy := dto.StockDiscrepancy{
+			ProductID:      balance.ProductID,
+			WarehouseID:    balance.WarehouseID,
+			LedgerQuantity: balance.LedgerQuantity,
+			StockQuantity:  balance.StockQuantity,
+			Difference:     balance.StockQuantity - balance.LedgerQuantity,
+		}
+
+		if product, ok := productsByID[balance.ProductID]; ok {
+			discrepancy.ProductName = product.Name
+			discrepancy.SKU = product.SKU
+		}
+
+		if balance.WarehouseID != nil {
+			discrepancy.WarehouseCode = warehouseCodes[*balance.WarehouseID]
+		}
+
+		response.Discrepancies = append(response.Discrepancies, discrepancy)
+	}
+
+	return response, nil
+}
+
+func mapKeys(set map[uint]bool) []uint {
+	keys := make([]uint, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// optionalActor returns nil for system changes, which have no actor
+func optionalActor(actorID uint) *uint {
+	if actorID == 0 {
+		return nil
+	}
+
+	return &actorID
+}
+
+func convertToStockMovementResponse(movement *models.StockMovement) dto.StockMovementResponse {
+	response := dto.StockMovementResponse{
+		ID:            movement.ID,
+		ProductID:     movement.ProductID,
+		WarehouseID:   movement.WarehouseID,
+		WarehouseCode: movement.Warehouse.Code,
+		Quantity:      movement.Quantity,
+		Reason:        string(movement.Reason),
+		ActorID:       movement.ActorID,
+		Reference:     movement.Reference,
+		CreatedAt:     movement.CreatedAt,
+	}
+
+	if movement.Actor != nil {
+		response.ActorEmail = movement.Actor.Email
+	}
+
+	return response
+}
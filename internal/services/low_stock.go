@@ -0,0 +1,68 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/events"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/notifications"
+	"gorm.io/gorm"
+)
+
+// lowStockAlerts returns an alert for each product whose on-hand stock fell to
+// or below its low-stock threshold with the given stock changes, which must
+// already be applied. Products already below their threshold don't alert again.
+func lowStockAlerts(tx *gorm.DB, defaultThreshold int, changes map[uint]int) ([]notifications.LowStockPayload, error) {
+	productIDs := make([]uint, 0, len(changes))
+	for productID, delta := range changes {
+		if delta < 0 {
+			productIDs = append(productIDs, productID)
+		}
+	}
+
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	var products []models.Product
+	if err := tx.Where("id IN ? AND is_bundle = ?", productIDs, false).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	var alerts []notifications.LowStockPayload
+	for i := range products {
+		threshold := defaultThreshold
+		if products[i].LowStockThreshold != nil {
+			threshold = *products[i].LowStockThreshold
+		}
+
+		if threshold <= 0 {
+			continue
+		}
+
+		before := products[i].Stock - changes[products[i].ID]
+		if before > threshold && products[i].Stock <= threshold {
+			alerts = append(alerts, notifications.LowStockPayload{
+				ProductID:   products[i].ID,
+				ProductName: products[i].Name,
+				SKU:         products[i].SKU,
+				Stock:       products[i].Stock,
+				Threshold:   threshold,
+				OccurredAt:  time.Now(),
+			})
+		}
+	}
+
+	return alerts, nil
+}
+
+// publishLowStock asks the notifier to alert staff about low stock. The stock
+// change is already committed, so failures are only logged.
+func publishLowStock(publisher events.Publisher, alerts []notifications.LowStockPayload) {
+	for i := range alerts {
+		if err := publisher.Publish(notifications.ProductLowStock, alerts[i], map[string]string{}); err != nil {
+			log.Printf("unable to publish low stock event: %v", err)
+		}
+	}
+}
@@ -3,9 +3,16 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 
+	"github.com/joefazee/learning-go-shop/internal/config"
 	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/events"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
 	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/money"
+	"github.com/joefazee/learning-go-shop/internal/notifications"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 	"gorm.io/gorm"
 )
@@ -13,21 +20,44 @@ import (
 var _ OrderServiceInterface = (*OrderService)(nil)
 
 type OrderService struct {
-	db *gorm.DB
+	db              *gorm.DB
+	config          *config.Config
+	eventPublisher  events.Publisher
+	downloadService DownloadServiceInterface
+	media           interfaces.UploadProvider
 }
 
 // NewOrderService creates the order service type
-func NewOrderService(db *gorm.DB) *OrderService {
-	return &OrderService{db: db}
+func NewOrderService(db *gorm.DB,
+	config *config.Config,
+	eventPublisher events.Publisher,
+	downloadService DownloadServiceInterface,
+	media interfaces.UploadProvider,
+) *OrderService {
+	return &OrderService{
+		db:              db,
+		config:          config,
+		eventPublisher:  eventPublisher,
+		downloadService: downloadService,
+		media:           media,
+	}
 }
 
-func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
+// CreateOrder places an order for the user's cart. The order is priced in the
+// localization's currency and keeps the exchange rate it was placed at. Each
+// line is allocated to warehouses with the configured strategy. Units the
+// products' stock policies allow selling beyond stock are backordered.
+func (s *OrderService) CreateOrder(userID uint, req *dto.CreateOrderRequest, l10n dto.Localization) (*dto.OrderResponse, error) {
 	var orderResponse *dto.OrderResponse
+	var lowStock []notifications.LowStockPayload
+	hasDownloads := false
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 
 		var cart models.Cart
-		if err := tx.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
+		if err := tx.Preload("CartItems.Product.BundleItems.Component").
+			Preload("CartItems.Product.Files").
+			Where("user_id = ?", userID).First(&cart).Error; err != nil {
 			return errors.New("cart not found")
 		}
 
@@ -35,57 +65,212 @@ func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
 			return errors.New("cart is empty")
 		}
 
-		// Calculate total and validate stock
-		var totalAmount float64
-		var orderItems []models.OrderItem
+		products := make([]*models.Product, len(cart.CartItems))
+		for i := range cart.CartItems {
+			products[i] = &cart.CartItems[i].Product
+		}
+
+		prices, err := loadPriceList(tx, s.config.Store.BaseCurrency, l10n.Currency, products...)
+		if err != nil {
+			return err
+		}
+
+		held, err := heldStock(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		// Calculate total and collect the stock each line consumes
+		var totalAmount money.Money
+		orderItems := make([]models.OrderItem, 0, len(cart.CartItems))
+		required := make(map[uint]int)
+		backorders := make(map[uint]int)
+		lines := make([]map[uint]int, 0, len(cart.CartItems))
+		productNames := make(map[uint]string)
+		var digitalFiles []models.ProductFile
+		requiresShipping := false
 
 		for i := range cart.CartItems {
 			cartItem := &cart.CartItems[i]
+			product := &cartItem.Product
 
-			if cartItem.Product.Stock < cartItem.Quantity {
-				return fmt.Errorf("insufficient stock for product: %s", cartItem.Product.Name)
-			}
-
-			itemTotal := float64(cartItem.Quantity) * cartItem.Product.Price
-			totalAmount += itemTotal
+			price := prices.price(product)
+			totalAmount += price.Times(cartItem.Quantity)
 
-			orderItems = append(orderItems, models.OrderItem{
+			orderItem := models.OrderItem{
 				ProductID: cartItem.ProductID,
 				Quantity:  cartItem.Quantity,
-				Price:     cartItem.Product.Price,
-			})
+				Price:     price,
+			}
 
-			// Update product stock
-			cartItem.Product.Stock -= cartItem.Quantity
-			if err := tx.Save(&cartItem.Product).Error; err != nil {
-				return err
+			if product.IsDigital {
+				digitalFiles = append(digitalFiles, product.Files...)
+			} else {
+				requiresShipping = true
 			}
 
-			// Create order
-			order := models.Order{
-				UserID:      userID,
-				Status:      models.OrderStatusPending,
-				TotalAmount: totalAmount,
-				OrderItems:  orderItems,
+			productNames[product.ID] = product.Name
+			for j := range product.BundleItems {
+				bundleItem := &product.BundleItems[j]
+				productNames[bundleItem.ComponentID] = bundleItem.Component.Name
+				orderItem.Components = append(orderItem.Components, models.OrderItemComponent{
+					ProductID: bundleItem.ComponentID,
+					Quantity:  bundleItem.Quantity * cartItem.Quantity,
+				})
 			}
 
-			if err := tx.Create(&order).Error; err != nil {
-				return err
+			if !hasStock(product, cartItem.Quantity, held) {
+				return fmt.Errorf("%w for product: %s", ErrInsufficientStock, product.Name)
+			}
+
+			inStock := inStockQuantity(product, cartItem.Quantity, held)
+			if backordered := cartItem.Quantity - inStock; backordered > 0 {
+				orderItem.Backordered = backordered
+				orderItem.ExpectedShipDate = product.ExpectedShipDate
+				backorders[product.ID] += backordered
 			}
 
-			// Clear cart
-			if err := tx.Unscoped().Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
+			line := stockRequirements(product, inStock)
+			for productID, quantity := range line {
+				required[productID] += quantity
+			}
+			lines = append(lines, line)
+
+			orderItems = append(orderItems, orderItem)
+		}
+
+		// The stock held for this checkout is consumed by the order
+		if err := releaseStock(tx, userID); err != nil {
+			return err
+		}
+
+		// Update product stock and backorders, in id order so concurrent orders
+		// lock rows consistently. Units reserved by other checkouts cannot be
+		// sold.
+		productIDs := make([]uint, 0, len(required)+len(backorders))
+		for productID := range required {
+			productIDs = append(productIDs, productID)
+		}
+		for productID := range backorders {
+			if _, ok := required[productID]; !ok {
+				productIDs = append(productIDs, productID)
+			}
+		}
+		slices.Sort(productIDs)
+
+		for _, productID := range productIDs {
+			if quantity := required[productID]; quantity > 0 {
+				result := tx.Model(&models.Product{}).
+					Where("id = ? AND stock - reserved >= ?", productID, quantity).
+					Update("stock", gorm.Expr("stock - ?", quantity))
+				if result.Error != nil {
+					return result.Error
+				}
+
+				if result.RowsAffected == 0 {
+					return fmt.Errorf("%w for product: %s", ErrInsufficientStock, productNames[productID])
+				}
+			}
+
+			if quantity := backorders[productID]; quantity > 0 {
+				result := tx.Model(&models.Product{}).
+					Where("id = ? AND (backorder_limit = 0 OR backordered + ? <= backorder_limit)", productID, quantity).
+					Update("backordered", gorm.Expr("backordered + ?", quantity))
+				if result.Error != nil {
+					return result.Error
+				}
+
+				if result.RowsAffected == 0 {
+					return fmt.Errorf("%w for product: %s", ErrInsufficientStock, productNames[productID])
+				}
+			}
+		}
+
+		changes := make(map[uint]int, len(required))
+		for productID, quantity := range required {
+			changes[productID] = -quantity
+		}
+
+		alerts, err := lowStockAlerts(tx, s.config.Stock.LowStockThreshold, changes)
+		if err != nil {
+			return err
+		}
+		lowStock = alerts
+
+		// Create order
+		order := models.Order{
+			UserID:           userID,
+			Status:           models.OrderStatusPending,
+			TotalAmount:      totalAmount,
+			Currency:         prices.currency,
+			ExchangeRate:     prices.rate,
+			RequiresShipping: requiresShipping,
+			OrderItems:       orderItems,
+		}
+
+		if err := tx.Create(&order).Error; err != nil {
+			return err
+		}
+
+		// Pick the warehouses each line ships from and record the sales
+		var shipTo *shippingLocation
+		if req != nil && req.Latitude != nil && req.Longitude != nil {
+			shipTo = &shippingLocation{latitude: *req.Latitude, longitude: *req.Longitude}
+		}
+
+		allocations, err := allocateStock(tx, s.config.Warehouse.AllocationStrategy, shipTo, lines, models.StockMovement{
+			Reason:    models.StockMovementSale,
+			ActorID:   &userID,
+			Reference: fmt.Sprintf("order:%d", order.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		var orderAllocations []models.OrderItemAllocation
+		for i := range order.OrderItems {
+			for _, allocation := range allocations[i] {
+				allocation.OrderItemID = order.OrderItems[i].ID
+				orderAllocations = append(orderAllocations, allocation)
+			}
+		}
+
+		if len(orderAllocations) > 0 {
+			if err := tx.Create(&orderAllocations).Error; err != nil {
 				return err
 			}
+		}
 
-			response, err := s.getOrderResponse(tx, order.ID)
-			if err != nil {
+		// Grant downloads for the files of digital products
+		if len(digitalFiles) > 0 {
+			grants := make([]models.DownloadGrant, len(digitalFiles))
+			for i := range digitalFiles {
+				grants[i] = models.DownloadGrant{
+					OrderID:       order.ID,
+					UserID:        userID,
+					ProductFileID: digitalFiles[i].ID,
+					MaxDownloads:  s.config.Download.MaxDownloads,
+				}
+			}
+
+			if err := tx.Create(&grants).Error; err != nil {
 				return err
 			}
+			hasDownloads = true
+		}
+
+		// Clear cart
+		if err := tx.Unscoped().Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
+			return err
+		}
 
-			orderResponse = response
+		response, err := s.getOrderResponse(tx, order.ID, l10n.Locale)
+		if err != nil {
+			return err
 		}
 
+		orderResponse = response
+
 		return nil // Transaction successful
 	})
 
@@ -93,11 +278,53 @@ func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
 		return nil, err
 	}
 
+	if hasDownloads {
+		s.publishDownloadsReady(userID, orderResponse.ID)
+	}
+
+	publishLowStock(s.eventPublisher, lowStock)
+
 	return orderResponse, nil
 
 }
 
-func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+// publishDownloadsReady asks the notifier to email the download links of an
+// order. The order is already placed, so failures are only logged.
+func (s *OrderService) publishDownloadsReady(userID, orderID uint) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		log.Println(err)
+		return
+	}
+
+	downloads, err := s.downloadService.GetOrderDownloads(userID, orderID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	payload := notifications.DownloadsReadyPayload{
+		OrderID:   orderID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Links:     make([]notifications.DownloadLink, len(downloads)),
+	}
+	for i := range downloads {
+		payload.Links[i] = notifications.DownloadLink{
+			ProductName: downloads[i].ProductName,
+			FileName:    downloads[i].FileName,
+			URL:         downloads[i].URL,
+			ExpiresAt:   downloads[i].ExpiresAt,
+		}
+	}
+
+	if err := s.eventPublisher.Publish(notifications.OrderDownloadsReady, payload, map[string]string{}); err != nil {
+		log.Printf("unable to publish downloads ready event: %v", err)
+	}
+}
+
+func (s *OrderService) GetOrders(userID uint, page, limit int, locale string) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -116,7 +343,7 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 
 	s.db.Model(&models.Order{}).Where("user_id = ?", userID).Count(&total)
 
-	if err := s.db.Preload("OrderItems.Product.Category").
+	if err := preloadOrder(s.db, locale).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Offset(offset).Limit(limit).
@@ -126,8 +353,11 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 
 	response := make([]dto.OrderResponse, len(orders))
 	for i := range orders {
-		order := &orders[i]
-		response[i] = s.convertToOrderResponse(order)
+		order, err := s.convertToOrderResponse(s.db, &orders[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		response[i] = order
 	}
 
 	totalPages := int((total + int64(limit) - 1) / int64(limit))
@@ -141,67 +371,110 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 	return response, meta, nil
 }
 
-func (s *OrderService) GetOrder(userID, orderID uint) (*dto.OrderResponse, error) {
+func (s *OrderService) GetOrder(userID, orderID uint, locale string) (*dto.OrderResponse, error) {
 	var order models.Order
-	if err := s.db.Preload("OrderItems.Product.Category").
+	if err := preloadOrder(s.db, locale).
 		Where("id = ? AND user_id = ?", orderID, userID).
 		First(&order).Error; err != nil {
 		return nil, err
 	}
 
-	response := s.convertToOrderResponse(&order)
+	response, err := s.convertToOrderResponse(s.db, &order)
+	if err != nil {
+		return nil, err
+	}
 
 	return &response, nil
 }
 
-func (s *OrderService) getOrderResponse(tx *gorm.DB, orderID uint) (*dto.OrderResponse, error) {
+func (s *OrderService) getOrderResponse(tx *gorm.DB, orderID uint, locale string) (*dto.OrderResponse, error) {
 	var order models.Order
-	if err := tx.Preload("OrderItems.Product.Category").First(&order, orderID).Error; err != nil {
+	if err := preloadOrder(tx, locale).First(&order, orderID).Error; err != nil {
 		return nil, err
 	}
 
-	response := s.convertToOrderResponse(&order)
+	response, err := s.convertToOrderResponse(tx, &order)
+	if err != nil {
+		return nil, err
+	}
 
 	return &response, nil
 }
 
-func (s *OrderService) convertToOrderResponse(order *models.Order) dto.OrderResponse {
+func preloadOrder(db *gorm.DB, locale string) *gorm.DB {
+	db = preloadProduct(db, "OrderItems.Product", locale).
+		Preload("OrderItems.Components.Product").
+		Preload("OrderItems.Allocations.Warehouse")
+	if locale != "" && locale != models.DefaultLocale {
+		db = db.Preload("OrderItems.Components.Product.Translations", "locale = ?", locale)
+	}
+
+	return db
+}
+
+// convertToOrderResponse prices the ordered products in the order's currency
+// at the rate the order was placed at
+func (s *OrderService) convertToOrderResponse(db *gorm.DB, order *models.Order) (dto.OrderResponse, error) {
+	products := make([]*models.Product, len(order.OrderItems))
+	for i := range order.OrderItems {
+		products[i] = &order.OrderItems[i].Product
+	}
+
+	prices, err := newPriceList(db, order.Currency, order.ExchangeRate, products...)
+	if err != nil {
+		return dto.OrderResponse{}, err
+	}
+
 	orderItems := make([]dto.OrderItemResponse, len(order.OrderItems))
 	for i := range order.OrderItems {
-		item := order.OrderItems[i]
+		item := &order.OrderItems[i]
 
 		orderItems[i] = dto.OrderItemResponse{
-			ID: item.ID,
-			Product: dto.ProductResponse{
-				ID:          item.Product.ID,
-				CategoryID:  item.Product.CategoryID,
-				Name:        item.Product.Name,
-				Description: item.Product.Description,
-				Price:       item.Product.Price,
-				Stock:       item.Product.Stock,
-				SKU:         item.Product.SKU,
-				IsActive:    item.Product.IsActive,
-				Category: dto.CategoryResponse{
-					ID:          item.Product.Category.ID,
-					Name:        item.Product.Category.Name,
-					Description: item.Product.Category.Description,
-					IsActive:    item.Product.Category.IsActive,
-				},
-			},
+			ID:       item.ID,
+			Product:  convertToProductResponse(&item.Product, prices, s.media),
 			Quantity: item.Quantity,
 			Price:    item.Price,
 
-			CreatedAt: item.CreatedAt,
+			Backordered:      item.Backordered,
+			ExpectedShipDate: item.ExpectedShipDate,
+			CreatedAt:        item.CreatedAt,
+		}
+
+		if len(item.Components) > 0 {
+			components := make([]dto.OrderItemComponentResponse, len(item.Components))
+			for j := range item.Components {
+				name, _ := productText(&item.Components[j].Product)
+				components[j] = dto.OrderItemComponentResponse{
+					ProductID: item.Components[j].ProductID,
+					Name:      name,
+					SKU:       item.Components[j].Product.SKU,
+					Quantity:  item.Components[j].Quantity,
+				}
+			}
+			orderItems[i].Components = components
+		}
+
+		orderItems[i].Allocations = make([]dto.OrderItemAllocationResponse, len(item.Allocations))
+		for j := range item.Allocations {
+			orderItems[i].Allocations[j] = dto.OrderItemAllocationResponse{
+				WarehouseID:   item.Allocations[j].WarehouseID,
+				WarehouseCode: item.Allocations[j].Warehouse.Code,
+				ProductID:     item.Allocations[j].ProductID,
+				Quantity:      item.Allocations[j].Quantity,
+			}
 		}
 	}
 
 	return dto.OrderResponse{
-		ID:          order.ID,
-		UserID:      order.UserID,
-		Status:      string(order.Status),
-		TotalAmount: order.TotalAmount,
-		OrderItems:  orderItems,
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
-	}
+		ID:               order.ID,
+		UserID:           order.UserID,
+		Status:           string(order.Status),
+		TotalAmount:      order.TotalAmount,
+		Currency:         order.Currency,
+		ExchangeRate:     order.ExchangeRate,
+		RequiresShipping: order.RequiresShipping,
+		OrderItems:       orderItems,
+		CreatedAt:        order.CreatedAt,
+		UpdatedAt:        order.UpdatedAt,
+	}, nil
 }
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+)
+
+var (
+	ErrProductImageNotFound = errors.New("product image not found")
+	ErrInvalidImageOrder    = errors.New("image order must list each of the product's images exactly once")
+)
+
+// Images are kept in position order, starting at 0, and every product with
+// images has exactly one primary image. Changes lock the product row so
+// concurrent changes to its images are applied one at a time.
+
+// AddProductImage adds an uploaded image after the product's other images.
+// The first image a product gets is its primary image. If the image can't be
+// added, its use of the uploaded files is released.
+func (s *ProductService) AddProductImage(productID uint, uploaded *UploadedImage, altText string) (*dto.ProductImageResponse, error) {
+	image := models.ProductImage{
+		ProductID:  productID,
+		URL:        uploaded.URL,
+		AltText:    altText,
+		Renditions: uploaded.Renditions,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.ProductImage{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		image.Position = int(count)
+		image.IsPrimary = count == 0
+
+		if uploaded.ContentHash != "" {
+			image.ContentHash = &uploaded.ContentHash
+		}
+
+		return tx.Create(&image).Error
+	})
+	if err != nil {
+		s.releaseUploadedImage(uploaded)
+		return nil, err
+	}
+
+	response := convertToProductImageResponse(&image, s.media)
+	return &response, nil
+}
+
+func (s *ProductService) UpdateProductImage(productID, imageID uint, req *dto.UpdateProductImageRequest) (*dto.ProductImageResponse, error) {
+	image, err := s.findProductImage(s.db, productID, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(image).Update("alt_text", req.AltText).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToProductImageResponse(image, s.media)
+	return &response, nil
+}
+
+// DeleteProductImage removes an image and returns the paths of the stored
+// files no image uses any more, which the caller is responsible for deleting.
+// If it was the primary image, the next image in order becomes primary.
+func (s *ProductService) DeleteProductImage(productID, imageID uint) ([]string, error) {
+	var paths []string
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		image, err := s.findProductImage(tx, productID, imageID)
+		if err != nil {
+			return err
+		}
+
+		// Renditions are removed with the image by the foreign key
+		if err := tx.Unscoped().Delete(image).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.ProductImage{}).
+			Where("product_id = ? AND position > ?", productID, image.Position).
+			Update("position", gorm.Expr("position - 1")).Error; err != nil {
+			return err
+		}
+
+		if image.IsPrimary {
+			if err := tx.Model(&models.ProductImage{}).
+				Where("product_id = ? AND position = 0", productID).
+				Update("is_primary", true).Error; err != nil {
+				return err
+			}
+		}
+
+		paths, err = releaseImageFiles(tx, image)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// ReorderProductImages puts the product's images in the order given
+func (s *ProductService) ReorderProductImages(productID uint, imageIDs []uint) ([]dto.ProductImageResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		var existing []uint
+		if err := tx.Model(&models.ProductImage{}).Where("product_id = ?", productID).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+
+		if len(imageIDs) != len(existing) {
+			return ErrInvalidImageOrder
+		}
+
+		positions := make(map[uint]int, len(imageIDs))
+		for i, id := range imageIDs {
+			if _, seen := positions[id]; seen {
+				return ErrInvalidImageOrder
+			}
+			positions[id] = i
+		}
+
+		for _, id := range existing {
+			if _, ok := positions[id]; !ok {
+				return ErrInvalidImageOrder
+			}
+		}
+
+		for id, position := range positions {
+			if err := tx.Model(&models.ProductImage{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getProductImages(productID)
+}
+
+// SetPrimaryProductImage makes an image the product's primary image, in place
+// of the current one
+func (s *ProductService) SetPrimaryProductImage(productID, imageID uint) ([]dto.ProductImageResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		if _, err := s.findProductImage(tx, productID, imageID); err != nil {
+			return err
+		}
+
+		// The current primary is cleared first, as only one may exist at a time
+		if err := tx.Model(&models.ProductImage{}).
+			Where("product_id = ? AND is_primary AND id <> ?", productID, imageID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ProductImage{}).Where("id = ?", imageID).Update("is_primary", true).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getProductImages(productID)
+}
+
+func (s *ProductService) findProductImage(tx *gorm.DB, productID, imageID uint) (*models.ProductImage, error) {
+	var image models.ProductImage
+	if err := tx.Preload("Renditions").
+		Where("id = ? AND product_id = ?", imageID, productID).
+		First(&image).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductImageNotFound
+		}
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+func (s *ProductService) getProductImages(productID uint) ([]dto.ProductImageResponse, error) {
+	var images []models.ProductImage
+	if err := s.db.Preload("Renditions").
+		Where("product_id = ?", productID).
+		Order("position, id").
+		Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ProductImageResponse, len(images))
+	for i := range images {
+		response[i] = convertToProductImageResponse(&images[i], s.media)
+	}
+
+	return response, nil
+}
+
+// releaseUploadedImage gives up the use of an uploaded image's files counted
+// when it was stored, deleting them if no other image uses them
+func (s *ProductService) releaseUploadedImage(uploaded *UploadedImage) {
+	image := models.ProductImage{URL: uploaded.URL, Renditions: uploaded.Renditions}
+	if uploaded.ContentHash != "" {
+		image.ContentHash = &uploaded.ContentHash
+	}
+
+	var paths []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		paths, err = releaseImageFiles(tx, &image)
+		return err
+	})
+	if err != nil {
+		log.Printf("unable to release uploaded image %s: %v", uploaded.URL, err)
+		return
+	}
+
+	deleteFiles(s.media, paths)
+}
+
+// retainImageObject counts another image using the files stored for hash
+func retainImageObject(tx *gorm.DB, hash string) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_hash"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"ref_count": gorm.Expr("image_objects.ref_count + 1")}),
+	}).Create(&models.ImageObject{ContentHash: hash, RefCount: 1}).Error
+}
+
+// releaseImageFiles drops a deleted image's use of its stored files and
+// returns the paths to delete once the transaction commits. Shared files are
+// only returned for their last image, and images from before content hashing
+// always own theirs.
+func releaseImageFiles(tx *gorm.DB, image *models.ProductImage) ([]string, error) {
+	if image.ContentHash != nil {
+		var object models.ImageObject
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("content_hash = ?", *image.ContentHash).
+			First(&object).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Without a count it is unknown whether other images use the
+			// files, so they are left for upload garbage collection
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if object.RefCount > 1 {
+			return nil, tx.Model(&object).Update("ref_count", gorm.Expr("ref_count - 1")).Error
+		}
+
+		if err := tx.Delete(&object).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	paths := []string{image.URL}
+	for _, rendition := range image.Renditions {
+		paths = append(paths, rendition.Path)
+	}
+
+	return paths, nil
+}
+
+// lockProduct locks a product's row for the rest of the transaction
+func lockProduct(tx *gorm.DB, productID uint) error {
+	var product models.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return fmt.Errorf("unable to lock product %d: %w", productID, err)
+	}
+
+	return nil
+}
@@ -1,30 +1,54 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
 	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
 	"github.com/joefazee/learning-go-shop/internal/models"
 	"github.com/joefazee/learning-go-shop/internal/utils"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrProductNotFound  = errors.New("product not found")
+	ErrPreorderShipDate = errors.New("pre-orders need an expected ship date")
+)
+
 var _ ProductServiceInterface = (*ProductService)(nil)
 
 type ProductService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	config *config.Config
+	// media resolves the URLs of product images
+	media interfaces.UploadProvider
 }
 
-func NewProductService(db *gorm.DB) *ProductService {
-	return &ProductService{db: db}
+func NewProductService(db *gorm.DB, config *config.Config, media interfaces.UploadProvider) *ProductService {
+	return &ProductService{db: db, config: config, media: media}
 }
 
-func (s *ProductService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+func (s *ProductService) CreateCategory(actorID uint, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
 
 	category := models.Category{
 		Name:        req.Name,
 		Description: req.Description,
 	}
 
-	if err := s.db.Create(&category).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&category).Error; err != nil {
+			return err
+		}
+
+		return recordRevision(tx, actorID, RevisionEntityCategory, category.ID, models.RevisionActionCreate, nil, nil)
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -39,28 +63,28 @@ func (s *ProductService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.Ca
 
 }
 
-func (s *ProductService) GetCategories() ([]dto.CategoryResponse, error) {
+// GetCategories returns the active categories with their names and
+// descriptions in locale, falling back to the default locale
+func (s *ProductService) GetCategories(locale string) ([]dto.CategoryResponse, error) {
+	query := s.db.Where("is_active = ?", true)
+	if locale != "" && locale != models.DefaultLocale {
+		query = query.Preload("Translations", "locale = ?", locale)
+	}
+
 	var categories []models.Category
-	if err := s.db.Where("is_active = ?", true).Find(&categories).Error; err != nil {
+	if err := query.Find(&categories).Error; err != nil {
 		return nil, err
 	}
 
 	response := make([]dto.CategoryResponse, len(categories))
 	for i := range categories {
-		response[i] = dto.CategoryResponse{
-			ID:          categories[i].ID,
-			Name:        categories[i].Name,
-			Description: categories[i].Description,
-			IsActive:    categories[i].IsActive,
-			CreatedAt:   categories[i].CreatedAt,
-			UpdatedAt:   categories[i].UpdatedAt,
-		}
+		response[i] = convertToCategoryResponse(&categories[i])
 	}
 
 	return response, nil
 }
 
-func (s *ProductService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+func (s *ProductService) UpdateCategory(actorID, id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
 
 	var category models.Category
 	if err := s.db.First(&category, id).Error; err != nil {
@@ -73,7 +97,11 @@ func (s *ProductService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest)
 		category.IsActive = *req.IsActive
 	}
 
-	if err := s.db.Save(&category).Error; err != nil {
+	err := trackRevision(s.db, actorID, RevisionEntityCategory, id, models.RevisionActionUpdate, func(tx *gorm.DB) error {
+		return tx.Save(&category).Error
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -87,58 +115,130 @@ func (s *ProductService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest)
 	}, nil
 }
 
-func (s *ProductService) DeleteCategory(id uint) error {
-	return s.db.Delete(&models.Category{}, id).Error
+// DeleteCategory moves a category and its products to the trash. They share
+// one deleted_at timestamp so the products can be restored with the category.
+func (s *ProductService) DeleteCategory(actorID, id uint) error {
+	deletedAt := time.Now().Truncate(time.Microsecond)
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Category{}).Where("id = ?", id).Update("deleted_at", deletedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrCategoryNotFound
+		}
+
+		var productIDs []uint
+		if err := tx.Model(&models.Product{}).Where("category_id = ?", id).Pluck("id", &productIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Product{}).Where("id IN ?", productIDs).Update("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+
+		for _, productID := range productIDs {
+			if err := recordRevision(tx, actorID, RevisionEntityProduct, productID, models.RevisionActionDelete, nil, nil); err != nil {
+				return err
+			}
+		}
+
+		return recordRevision(tx, actorID, RevisionEntityCategory, id, models.RevisionActionDelete, nil, nil)
+	})
 }
 
-func (s *ProductService) CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
+func (s *ProductService) CreateProduct(actorID uint, req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
 	product := models.Product{
 		CategoryID:  req.CategoryID,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
-		Stock:       req.Stock,
 		SKU:         req.SKU,
+		IsDigital:   req.IsDigital,
+
+		LowStockThreshold: req.LowStockThreshold,
+	}
+
+	if err := applyStockPolicy(&product, req.StockPolicy, req.BackorderLimit, req.ExpectedShipDate); err != nil {
+		return nil, err
 	}
 
-	if err := s.db.Create(&product).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&product).Error; err != nil {
+			return err
+		}
+
+		// Stock given on the product is received at the default warehouse
+		if err := addDefaultStock(tx, &models.StockMovement{
+			ProductID: product.ID,
+			Quantity:  req.Stock,
+			Reason:    models.StockMovementRestock,
+			ActorID:   optionalActor(actorID),
+			Reference: "initial stock",
+		}); err != nil {
+			return err
+		}
+
+		return recordRevision(tx, actorID, RevisionEntityProduct, product.ID, models.RevisionActionCreate, nil, nil)
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	return s.GetProduct(product.ID)
+	return s.GetProduct(product.ID, dto.Localization{})
 }
 
-func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
-	if page < 1 {
-		page = 1
+func (s *ProductService) GetProducts(req *dto.ListProductsRequest) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+	if req.Page < 1 {
+		req.Page = 1
 	}
 
-	if limit < 1 {
-		limit = 10
+	if req.Limit < 1 {
+		req.Limit = 10
 	}
 
-	offset := (page - 1) * limit
+	offset := (req.Page - 1) * req.Limit
 	var products []models.Product
 	var total int64
 
 	s.db.Model(&models.Product{}).Where("is_active = ?", true).Count(&total)
 
-	if err := s.db.Preload("Category").Preload("Images").
-		Where("is_active = ?", true).
-		Offset(offset).Limit(limit).
+	query := preloadProduct(s.db, "", req.Localization.Locale).Where("is_active = ?", true)
+	switch req.Sort {
+	case "rating":
+		query = query.Order("rating_average DESC, rating_count DESC, id")
+	case "newest":
+		query = query.Order("created_at DESC")
+	}
+
+	if err := query.
+		Offset(offset).Limit(req.Limit).
 		Find(&products).Error; err != nil {
 		return nil, nil, err
 	}
 
+	priced := make([]*models.Product, len(products))
+	for i := range products {
+		priced[i] = &products[i]
+	}
+
+	prices, err := loadPriceList(s.db, s.config.Store.BaseCurrency, req.Localization.Currency, priced...)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	response := make([]dto.ProductResponse, len(products))
 	for i := range products {
-		response[i] = s.convertToProductResponse(&products[i])
+		response[i] = convertToProductResponse(&products[i], prices, s.media)
 	}
 
-	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
 	meta := &utils.PaginationMeta{
-		Page:       page,
-		Limit:      limit,
+		Page:       req.Page,
+		Limit:      req.Limit,
 		Total:      total,
 		TotalPages: totalPages,
 	}
@@ -146,17 +246,24 @@ func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *u
 	return response, meta, nil
 }
 
-func (s *ProductService) GetProduct(id uint) (*dto.ProductResponse, error) {
+// GetProduct returns a product presented in the given currency and locale.
+// The zero Localization selects the base currency and default locale.
+func (s *ProductService) GetProduct(id uint, l10n dto.Localization) (*dto.ProductResponse, error) {
 	var product models.Product
-	if err := s.db.Preload("Category").Preload("Images").First(&product, id).Error; err != nil {
+	if err := preloadProduct(s.db, "", l10n.Locale).First(&product, id).Error; err != nil {
+		return nil, err
+	}
+
+	prices, err := loadPriceList(s.db, s.config.Store.BaseCurrency, l10n.Currency, &product)
+	if err != nil {
 		return nil, err
 	}
 
-	response := s.convertToProductResponse(&product)
+	response := convertToProductResponse(&product, prices, s.media)
 	return &response, nil
 }
 
-func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
+func (s *ProductService) UpdateProduct(actorID, id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
 	var product models.Product
 	if err := s.db.First(&product, id).Error; err != nil {
 		return nil, err
@@ -166,38 +273,85 @@ func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (
 	product.Name = req.Name
 	product.Description = req.Description
 	product.Price = req.Price
-	product.Stock = req.Stock
 	if req.IsActive != nil {
 		product.IsActive = *req.IsActive
 	}
+	if req.IsDigital != nil {
+		product.IsDigital = *req.IsDigital
+	}
+	product.LowStockThreshold = req.LowStockThreshold
+	if err := applyStockPolicy(&product, req.StockPolicy, req.BackorderLimit, req.ExpectedShipDate); err != nil {
+		return nil, err
+	}
 
-	if err := s.db.Save(&product).Error; err != nil {
+	err := trackRevision(s.db, actorID, RevisionEntityProduct, id, models.RevisionActionUpdate, func(tx *gorm.DB) error {
+		// stock, reserved and backordered only change through stock movements,
+		// checkouts and orders, don't overwrite them with the values read above
+		return tx.Omit("stock", "reserved", "backordered").Save(&product).Error
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	return s.GetProduct(id)
+	return s.GetProduct(id, dto.Localization{})
 }
 
-func (s *ProductService) DeleteProduct(id uint) error {
-	return s.db.Delete(&models.Product{}, id).Error
+func (s *ProductService) DeleteProduct(actorID, id uint) error {
+	var product models.Product
+	if err := s.db.First(&product, id).Error; err != nil {
+		return ErrProductNotFound
+	}
+
+	return trackRevision(s.db, actorID, RevisionEntityProduct, id, models.RevisionActionDelete, func(tx *gorm.DB) error {
+		return tx.Delete(&product).Error
+	})
 }
 
-func (s *ProductService) AddProductImage(productID uint, url, altText string) error {
+func (s *ProductService) AddProductFile(productID uint, storageKey, fileName, contentType string, size int64) (*dto.ProductFileResponse, error) {
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+
+	if !product.IsDigital {
+		return nil, errors.New("files can only be attached to digital products")
+	}
+
+	file := models.ProductFile{
+		ProductID:   productID,
+		StorageKey:  storageKey,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+	}
+
+	if err := s.db.Create(&file).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToProductFileResponse(&file)
+	return &response, nil
+}
 
-	var count int64
-	s.db.Model(&models.ProductImage{}).Where("product_id = ?", productID).Count(&count)
+func (s *ProductService) GetProductFiles(productID uint) ([]dto.ProductFileResponse, error) {
+	var files []models.ProductFile
+	if err := s.db.Where("product_id = ?", productID).Order("created_at").Find(&files).Error; err != nil {
+		return nil, err
+	}
 
-	image := models.ProductImage{
-		ProductID: productID,
-		URL:       url,
-		AltText:   altText,
-		IsPrimary: count == 0, // First image is primary
+	response := make([]dto.ProductFileResponse, len(files))
+	for i := range files {
+		response[i] = convertToProductFileResponse(&files[i])
 	}
 
-	return s.db.Create(&image).Error
+	return response, nil
 }
 
-func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
+// SearchProducts returns a page of the products matching a query. When few
+// do, the query may be misspelled, so the corrected query is returned too if
+// there is one.
+func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, string, error) {
 
 	if req.Page < 1 {
 		req.Page = 1
@@ -209,50 +363,129 @@ func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.P
 
 	offset := (req.Page - 1) * req.Limit
 
-	// build query
-	query := s.db.Model(&models.Product{}).
-		Select("products.*, ts_rank(search_vector, plainto_tsquery('english', ?)) as rank", req.Query).
-		Where("search_vector @@ plainto_tsquery('english', ?)", req.Query).
-		Where("is_active = ?", true)
-
-	if req.CategoryID != nil {
-		query = query.Where("category_id = ?", *req.CategoryID)
+	prices, err := loadPriceList(s.db, s.config.Store.BaseCurrency, req.Localization.Currency)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	if req.MinPrice != nil {
-		query = query.Where("price >= ?", *req.MinPrice)
+	locale := req.Localization.Locale
+	if locale == "" {
+		locale = models.DefaultLocale
 	}
 
-	if req.MaxPrice != nil {
-		query = query.Where("price <= ?", *req.MaxPrice)
+	// build query. Products translated into the locale are matched on the
+	// translation's search vector, the rest on the default locale's, each
+	// with the query parsed by the matching text search configuration.
+	const searchVector = "COALESCE(pt.search_vector, products.search_vector)"
+	const searchQuery = "plainto_tsquery(locale_search_config(COALESCE(pt.locale, ?)), ?)"
+
+	search := func(selects string, args ...interface{}) *gorm.DB {
+		query := s.db.Model(&models.Product{}).
+			Joins("LEFT JOIN product_translations pt ON pt.product_id = products.id AND pt.locale = ?", locale).
+			Select(selects, args...).
+			Where("products.is_active = ?", true)
+
+		if req.CategoryID != nil {
+			query = query.Where("category_id = ?", *req.CategoryID)
+		}
+
+		// Price filters are converted to the base currency at the current rate
+		if req.MinPrice != nil {
+			query = query.Where("price >= ?", prices.toBase(*req.MinPrice))
+		}
+
+		if req.MaxPrice != nil {
+			query = query.Where("price <= ?", prices.toBase(*req.MaxPrice))
+		}
+
+		return query
 	}
 
+	query := search("products.*, ts_rank("+searchVector+", "+searchQuery+") as rank", models.DefaultLocale, req.Query).
+		Where(searchVector+" @@ "+searchQuery, models.DefaultLocale, req.Query)
+	order := "rank DESC, products.created_at DESC" // order by relevance
+
 	// Count total results
 	var total int64
 	query.Count(&total)
 
+	// Searches that found products feed the popular search suggestions. Later
+	// pages are the same search, so only the first is counted.
+	if total > 0 && req.Page == 1 {
+		if err := recordSearchQuery(s.db, locale, req.Query, total); err != nil {
+			log.Printf("unable to record search query: %v", err)
+		}
+	}
+
+	var corrected string
+
+	// Few matches may mean the query is misspelled, so products matching its
+	// correction or with a similar name or SKU follow the ones that matched
+	if total < int64(s.config.Search.FuzzyThreshold) {
+		corrected, err = s.correctSearchQuery(req.Query)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		rankQuery := req.Query
+		if corrected != "" {
+			rankQuery = corrected
+		}
+
+		// The similar names and SKUs are looked up on their own so their
+		// trigram indexes apply
+		const similarProducts = `products.id IN (
+			SELECT id FROM products
+			WHERE deleted_at IS NULL AND is_active = true AND (? <% lower(name) OR ? <% lower(sku))
+			UNION
+			SELECT product_id FROM product_translations WHERE locale = ? AND ? <% lower(name)
+		)`
+		const similarity = "GREATEST(word_similarity(?, lower(COALESCE(pt.name, products.name))), word_similarity(?, lower(products.sku)))"
+
+		similar := normalizeSearchQuery(req.Query)
+		query = search("products.*, ts_rank("+searchVector+", "+searchQuery+") as rank, "+
+			"NOT ("+searchVector+" @@ "+searchQuery+") as fuzzy, "+similarity+" as similarity",
+			models.DefaultLocale, rankQuery, models.DefaultLocale, req.Query, similar, similar).
+			Where(s.db.Where(searchVector+" @@ "+searchQuery, models.DefaultLocale, req.Query).
+				Or(searchVector+" @@ "+searchQuery, models.DefaultLocale, rankQuery).
+				Or(similarProducts, similar, similar, locale, similar))
+		order = "fuzzy, rank DESC, similarity DESC, products.created_at DESC"
+
+		query.Count(&total)
+	}
+
 	// Execute query with ranking and create product slices
 	type productsWithRank struct {
 		models.Product
-		Rank float32 `gorm:"column:rank"`
+		Rank  float32 `gorm:"column:rank"`
+		Fuzzy bool    `gorm:"column:fuzzy"`
 	}
 	var rows []productsWithRank
-	if err := query.
-		Order("rank DESC, created_at DESC"). // order by relevance
-		Preload("Category").
-		Preload("Images").
+	if err := preloadProduct(query, "", locale).
+		Order(order).
 		Offset(offset).
 		Limit(req.Limit).
 		Find(&rows).Error; err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
+	}
+
+	priced := make([]*models.Product, len(rows))
+	for i := range rows {
+		priced[i] = &rows[i].Product
+	}
+
+	prices, err = newPriceList(s.db, prices.currency, prices.rate, priced...)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
 	// Build output response
 	results := make([]dto.ProductSearchResult, len(rows))
 	for i := range rows {
 		results[i] = dto.ProductSearchResult{
-			ProductResponse: s.convertToProductResponse(&rows[i].Product),
+			ProductResponse: convertToProductResponse(&rows[i].Product, prices, s.media),
 			Rank:            rows[i].Rank,
+			Fuzzy:           rows[i].Fuzzy,
 		}
 	}
 
@@ -265,40 +498,232 @@ func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.P
 		TotalPages: totalPages,
 	}
 
-	return results, meta, nil
+	return results, meta, corrected, nil
+}
+
+func (s *ProductService) SetBundle(actorID, productID uint, req *dto.SetBundleRequest) (*dto.ProductResponse, error) {
+	switch models.BundlePricing(req.Pricing) {
+	case models.BundlePricingFixed:
+	case models.BundlePricingPercentage:
+		if req.Percentage <= 0 || req.Percentage > 100 {
+			return nil, errors.New("percentage must be between 0 and 100 for percentage priced bundles")
+		}
+	default:
+		return nil, fmt.Errorf("invalid bundle pricing: %s", req.Pricing)
+	}
+
+	if len(req.Items) == 0 {
+		return nil, errors.New("a bundle needs at least one component")
+	}
+
+	err := trackRevision(s.db, actorID, RevisionEntityProduct, productID, models.RevisionActionUpdate, func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.First(&product, productID).Error; err != nil {
+			return err
+		}
+
+		var usedAsComponent int64
+		tx.Model(&models.BundleItem{}).Where("component_id = ?", productID).Count(&usedAsComponent)
+		if usedAsComponent > 0 {
+			return errors.New("product is a component of another bundle")
+		}
+
+		items := make([]models.BundleItem, len(req.Items))
+		componentIDs := make([]uint, len(req.Items))
+		for i, item := range req.Items {
+			if item.ProductID == productID {
+				return errors.New("a bundle cannot contain itself")
+			}
+			componentIDs[i] = item.ProductID
+			items[i] = models.BundleItem{
+				BundleID:    productID,
+				ComponentID: item.ProductID,
+				Quantity:    item.Quantity,
+			}
+		}
+
+		var components []models.Product
+		if err := tx.Where("id IN ?", componentIDs).Find(&components).Error; err != nil {
+			return err
+		}
+
+		if len(components) != len(componentIDs) {
+			return errors.New("bundle components must be distinct existing products")
+		}
+
+		for i := range components {
+			if components[i].IsBundle {
+				return fmt.Errorf("product %s is a bundle and cannot be a component", components[i].Name)
+			}
+			if components[i].IsDigital {
+				return fmt.Errorf("product %s is digital and cannot be a component", components[i].Name)
+			}
+		}
+
+		if err := tx.Where("bundle_id = ?", productID).Delete(&models.BundleItem{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&items).Error; err != nil {
+			return err
+		}
+
+		percentage := req.Percentage
+		if percentage == 0 {
+			percentage = 100
+		}
+
+		return tx.Model(&product).Updates(map[string]interface{}{
+			"is_bundle":         true,
+			"bundle_pricing":    req.Pricing,
+			"bundle_percentage": percentage,
+		}).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProduct(productID, dto.Localization{})
+}
+
+func (s *ProductService) RemoveBundle(actorID, productID uint) (*dto.ProductResponse, error) {
+	err := trackRevision(s.db, actorID, RevisionEntityProduct, productID, models.RevisionActionUpdate, func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_id = ?", productID).Delete(&models.BundleItem{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Product{}).Where("id = ?", productID).Updates(map[string]interface{}{
+			"is_bundle":      false,
+			"bundle_pricing": models.BundlePricingFixed,
+		}).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProduct(productID, dto.Localization{})
+}
+
+// applyStockPolicy sets how a product sells beyond its stock, deny when no
+// policy is given
+func applyStockPolicy(product *models.Product, policy string, backorderLimit int, expectedShipDate *time.Time) error {
+	product.StockPolicy = models.StockPolicy(policy)
+	if product.StockPolicy == "" {
+		product.StockPolicy = models.StockPolicyDeny
+	}
+
+	if product.StockPolicy == models.StockPolicyPreorder && expectedShipDate == nil {
+		return ErrPreorderShipDate
+	}
+
+	product.BackorderLimit = backorderLimit
+	product.ExpectedShipDate = expectedShipDate
+	return nil
+}
+
+// mediaURL resolves the URL a stored file is served from. A file that can't be
+// resolved is logged and given no URL.
+func mediaURL(media interfaces.UploadProvider, path string) string {
+	url, err := media.URL(path)
+	if err != nil {
+		log.Printf("unable to resolve the URL of %s: %v", path, err)
+		return ""
+	}
+
+	return url
+}
+
+func convertToProductImageResponse(image *models.ProductImage, media interfaces.UploadProvider) dto.ProductImageResponse {
+	renditions := make([]dto.ProductImageRenditionResponse, len(image.Renditions))
+	for i := range image.Renditions {
+		renditions[i] = dto.ProductImageRenditionResponse{
+			Name:   image.Renditions[i].Name,
+			Format: image.Renditions[i].Format,
+			URL:    mediaURL(media, image.Renditions[i].Path),
+			Width:  image.Renditions[i].Width,
+			Height: image.Renditions[i].Height,
+		}
+	}
+
+	return dto.ProductImageResponse{
+		ID:         image.ID,
+		URL:        mediaURL(media, image.URL),
+		AltText:    image.AltText,
+		IsPrimary:  image.IsPrimary,
+		Position:   image.Position,
+		Renditions: renditions,
+		CreatedAt:  image.CreatedAt,
+	}
 }
 
-func (s *ProductService) convertToProductResponse(product *models.Product) dto.ProductResponse {
+// convertToProductResponse presents a product with the given prices and in the
+// locale of its loaded translations, if any
+func convertToProductResponse(product *models.Product, prices *priceList, media interfaces.UploadProvider) dto.ProductResponse {
 	images := make([]dto.ProductImageResponse, len(product.Images))
 	for i := range product.Images {
-		images[i] = dto.ProductImageResponse{
-			ID:        product.Images[i].ID,
-			URL:       product.Images[i].URL,
-			AltText:   product.Images[i].AltText,
-			IsPrimary: product.Images[i].IsPrimary,
-			CreatedAt: product.Images[i].CreatedAt,
+		images[i] = convertToProductImageResponse(&product.Images[i], media)
+	}
+
+	name, description := productText(product)
+
+	response := dto.ProductResponse{
+		ID:             product.ID,
+		CategoryID:     product.CategoryID,
+		Name:           name,
+		Description:    description,
+		Price:          prices.price(product),
+		Currency:       prices.currency,
+		Stock:          availableStock(product, nil),
+		AvailableStock: availableStock(product, nil),
+		OnHandStock:    onHandStock(product),
+		SKU:            product.SKU,
+		IsActive:       product.IsActive,
+		IsDigital:      product.IsDigital,
+		IsBundle:       product.IsBundle,
+		RatingAverage:  product.RatingAverage,
+		RatingCount:    product.RatingCount,
+		Category:       convertToCategoryResponse(&product.Category),
+		Images:         images,
+		CreatedAt:      product.CreatedAt,
+		UpdatedAt:      product.UpdatedAt,
+
+		LowStockThreshold: product.LowStockThreshold,
+
+		StockPolicy:      string(stockPolicy(product)),
+		BackorderLimit:   product.BackorderLimit,
+		ExpectedShipDate: product.ExpectedShipDate,
+		Availability:     stockAvailability(product),
+	}
+
+	if product.IsBundle {
+		response.BundlePricing = string(product.BundlePricing)
+		response.BundlePercentage = product.BundlePercentage
+		response.BundleItems = make([]dto.BundleItemResponse, len(product.BundleItems))
+		for i := range product.BundleItems {
+			item := &product.BundleItems[i]
+			componentName, _ := productText(&item.Component)
+			response.BundleItems[i] = dto.BundleItemResponse{
+				ProductID: item.ComponentID,
+				Name:      componentName,
+				SKU:       item.Component.SKU,
+				Price:     prices.price(&item.Component),
+				Quantity:  item.Quantity,
+			}
 		}
 	}
 
-	return dto.ProductResponse{
-		ID:          product.ID,
-		CategoryID:  product.CategoryID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       product.Stock,
-		SKU:         product.SKU,
-		IsActive:    product.IsActive,
-		Category: dto.CategoryResponse{
-			ID:          product.Category.ID,
-			Name:        product.Category.Name,
-			Description: product.Category.Description,
-			IsActive:    product.Category.IsActive,
-			CreatedAt:   product.Category.CreatedAt,
-			UpdatedAt:   product.Category.UpdatedAt,
-		},
-		Images:    images,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+	return response
+}
+
+func convertToProductFileResponse(file *models.ProductFile) dto.ProductFileResponse {
+	return dto.ProductFileResponse{
+		ID:          file.ID,
+		ProductID:   file.ProductID,
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		CreatedAt:   file.CreatedAt,
 	}
 }
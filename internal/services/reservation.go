@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// heldStock returns the units of each product reserved by the user
+func heldStock(db *gorm.DB, userID uint) (map[uint]int, error) {
+	var reservations []models.StockReservation
+	if err := db.Where("user_id = ?", userID).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	held := make(map[uint]int, len(reservations))
+	for i := range reservations {
+		held[reservations[i].ProductID] = reservations[i].Quantity
+	}
+
+	return held, nil
+}
+
+// holdStock replaces the user's reservations with required, the units of each
+// product their checkout needs, held until expiresAt. Units the user already
+// holds are kept, so only the difference has to be available. It fails with
+// ErrInsufficientStock when another checkout holds the stock.
+func holdStock(tx *gorm.DB, userID uint, required map[uint]int, expiresAt time.Time) error {
+	var existing []models.StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		Find(&existing).Error; err != nil {
+		return err
+	}
+
+	delta := make(map[uint]int, len(required))
+	for productID, quantity := range required {
+		delta[productID] += quantity
+	}
+	for i := range existing {
+		delta[existing[i].ProductID] -= existing[i].Quantity
+	}
+
+	// Adjust the reserved counters in id order so concurrent checkouts lock
+	// product rows consistently
+	productIDs := make([]uint, 0, len(delta))
+	for productID := range delta {
+		productIDs = append(productIDs, productID)
+	}
+	slices.Sort(productIDs)
+
+	for _, productID := range productIDs {
+		quantity := delta[productID]
+		switch {
+		case quantity > 0:
+			result := tx.Model(&models.Product{}).
+				Where("id = ? AND stock - reserved >= ?", productID, quantity).
+				Update("reserved", gorm.Expr("reserved + ?", quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				var product models.Product
+				tx.Unscoped().Select("name").First(&product, productID)
+				return fmt.Errorf("%w for product: %s", ErrInsufficientStock, product.Name)
+			}
+		case quantity < 0:
+			if err := releaseReserved(tx, productID, -quantity); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&models.StockReservation{}).Error; err != nil {
+		return err
+	}
+
+	if len(required) == 0 {
+		return nil
+	}
+
+	reservations := make([]models.StockReservation, 0, len(required))
+	for _, productID := range productIDs {
+		if quantity := required[productID]; quantity > 0 {
+			reservations = append(reservations, models.StockReservation{
+				UserID:    userID,
+				ProductID: productID,
+				Quantity:  quantity,
+				ExpiresAt: expiresAt,
+			})
+		}
+	}
+
+	return tx.Create(&reservations).Error
+}
+
+// releaseStock drops all of the user's reservations
+func releaseStock(tx *gorm.DB, userID uint) error {
+	return holdStock(tx, userID, nil, time.Time{})
+}
+
+// releaseExpiredReservations drops the reservations that expired before the
+// given time and returns how many were released
+func releaseExpiredReservations(db *gorm.DB, before time.Time) (int, error) {
+	var released []models.StockReservation
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Returning{}).
+			Where("expires_at <= ?", before).
+			Delete(&released).Error; err != nil {
+			return err
+		}
+
+		quantities := make(map[uint]int)
+		for i := range released {
+			quantities[released[i].ProductID] += released[i].Quantity
+		}
+
+		productIDs := make([]uint, 0, len(quantities))
+		for productID := range quantities {
+			productIDs = append(productIDs, productID)
+		}
+		slices.Sort(productIDs)
+
+		for _, productID := range productIDs {
+			if err := releaseReserved(tx, productID, quantities[productID]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(released), nil
+}
+
+func releaseReserved(tx *gorm.DB, productID uint, quantity int) error {
+	return tx.Unscoped().Model(&models.Product{}).
+		Where("id = ?", productID).
+		Update("reserved", gorm.Expr("GREATEST(reserved - ?, 0)", quantity)).Error
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/events"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/notifications"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAlreadyReviewed = errors.New("you have already reviewed this product")
+
+const (
+	// reviewPerUserIndex is the unique index allowing one review of a product
+	// per user
+	reviewPerUserIndex = "idx_reviews_product_user"
+	// uniqueViolation is the SQLSTATE of a unique constraint violation
+	uniqueViolation = "23505"
+)
+
+var _ ReviewServiceInterface = (*ReviewService)(nil)
+
+type ReviewService struct {
+	db             *gorm.DB
+	eventPublisher events.Publisher
+}
+
+func NewReviewService(db *gorm.DB, eventPublisher events.Publisher) *ReviewService {
+	return &ReviewService{
+		db:             db,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (s *ReviewService) CreateReview(userID, productID uint, req *dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, errors.New("rating must be between 1 and 5")
+	}
+
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, errors.New("review body is required")
+	}
+
+	var product models.Product
+	if err := s.db.Where("is_active = ?", true).First(&product, productID).Error; err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Checked first to skip the work below, but only the unique index stops a
+	// parallel request reviewing the product too
+	var existing int64
+	if err := s.db.Model(&models.Review{}).Where("product_id = ? AND user_id = ?", productID, userID).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	if existing > 0 {
+		return nil, ErrAlreadyReviewed
+	}
+
+	// A review is verified when the user has received the product
+	var delivered int64
+	if err := s.db.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND orders.status = ? AND order_items.product_id = ?",
+			userID, models.OrderStatusDelivered, productID).
+		Count(&delivered).Error; err != nil {
+		return nil, err
+	}
+
+	review := models.Review{
+		ProductID:          productID,
+		UserID:             userID,
+		Rating:             req.Rating,
+		Title:              req.Title,
+		Body:               req.Body,
+		IsVerifiedPurchase: delivered > 0,
+		Status:             models.ReviewStatusPending,
+	}
+
+	if err := s.db.Create(&review).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == reviewPerUserIndex {
+			return nil, ErrAlreadyReviewed
+		}
+		return nil, err
+	}
+	review.User = user
+
+	payload := notifications.ReviewSubmittedPayload{
+		ReviewID:    review.ID,
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Rating:      review.Rating,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+	}
+	if err := s.eventPublisher.Publish(notifications.ReviewSubmitted, payload, map[string]string{}); err != nil {
+		log.Printf("unable to publish review submitted event: %v", err)
+	}
+
+	response := convertToReviewResponse(&review)
+	return &response, nil
+}
+
+func (s *ReviewService) GetProductReviews(productID uint, req *dto.ListReviewsRequest) ([]dto.ReviewResponse, *utils.PaginationMeta, error) {
+	query := s.db.Model(&models.Review{}).
+		Where("product_id = ? AND status = ?", productID, models.ReviewStatusApproved)
+
+	switch req.Sort {
+	case "helpful":
+		query = query.Order("helpful_count DESC, created_at DESC")
+	case "rating_desc":
+		query = query.Order("rating DESC, created_at DESC")
+	case "rating_asc":
+		query = query.Order("rating ASC, created_at DESC")
+	default:
+		query = query.Order("created_at DESC")
+	}
+
+	return s.paginateReviews(query, req.Page, req.Limit)
+}
+
+func (s *ReviewService) GetReviewsByStatus(status string, page, limit int) ([]dto.ReviewResponse, *utils.PaginationMeta, error) {
+	switch models.ReviewStatus(status) {
+	case "":
+		status = string(models.ReviewStatusPending)
+	case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected:
+	default:
+		return nil, nil, fmt.Errorf("invalid review status: %s", status)
+	}
+
+	// Oldest first, so the moderation queue is worked in submission order
+	query := s.db.Model(&models.Review{}).
+		Where("status = ?", status).
+		Order("created_at ASC")
+
+	return s.paginateReviews(query, page, limit)
+}
+
+func (s *ReviewService) ModerateReview(reviewID uint, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	status := models.ReviewStatus(req.Status)
+	if status != models.ReviewStatusApproved && status != models.ReviewStatusRejected {
+		return nil, fmt.Errorf("invalid review status: %s", req.Status)
+	}
+
+	var review models.Review
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&review, reviewID).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		review.Status = status
+		review.ModeratedAt = &now
+		if err := tx.Save(&review).Error; err != nil {
+			return err
+		}
+
+		return updateProductRating(tx, review.ProductID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getReview(review.ID)
+}
+
+func (s *ReviewService) MarkHelpful(userID, reviewID uint) (*dto.ReviewResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.Where("status = ?", models.ReviewStatusApproved).First(&review, reviewID).Error; err != nil {
+			return errors.New("review not found")
+		}
+
+		if review.UserID == userID {
+			return errors.New("you cannot vote on your own review")
+		}
+
+		vote := models.ReviewVote{ReviewID: reviewID, UserID: userID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&vote)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// Already voted
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Review{}).
+			Where("id = ?", reviewID).
+			Update("helpful_count", gorm.Expr("helpful_count + 1")).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getReview(reviewID)
+}
+
+func (s *ReviewService) getReview(reviewID uint) (*dto.ReviewResponse, error) {
+	var review models.Review
+	if err := s.db.Preload("User").First(&review, reviewID).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToReviewResponse(&review)
+	return &response, nil
+}
+
+func (s *ReviewService) paginateReviews(query *gorm.DB, page, limit int) ([]dto.ReviewResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	var total int64
+	query.Count(&total)
+
+	var reviews []models.Review
+	if err := query.Preload("User").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&reviews).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.ReviewResponse, len(reviews))
+	for i := range reviews {
+		response[i] = convertToReviewResponse(&reviews[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+// updateProductRating recomputes the rating aggregates of a product from its approved reviews
+func updateProductRating(tx *gorm.DB, productID uint) error {
+	approved := tx.Model(&models.Review{}).
+		Where("product_id = ? AND status = ?", productID, models.ReviewStatusApproved)
+
+	return tx.Model(&models.Product{}).Where("id = ?", productID).Updates(map[string]interface{}{
+		"rating_average": gorm.Expr("COALESCE((?), 0)", approved.Session(&gorm.Session{}).Select("ROUND(AVG(rating), 2)")),
+		"rating_count":   gorm.Expr("(?)", approved.Session(&gorm.Session{}).Select("COUNT(*)")),
+	}).Error
+}
+
+func convertToReviewResponse(review *models.Review) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:                 review.ID,
+		ProductID:          review.ProductID,
+		UserID:             review.UserID,
+		AuthorName:         reviewAuthorName(&review.User),
+		Rating:             review.Rating,
+		Title:              review.Title,
+		Body:               review.Body,
+		IsVerifiedPurchase: review.IsVerifiedPurchase,
+		Status:             string(review.Status),
+		HelpfulCount:       review.HelpfulCount,
+		CreatedAt:          review.CreatedAt,
+		UpdatedAt:          review.UpdatedAt,
+	}
+}
+
+// reviewAuthorName shortens the last name to an initial, e.g. "Jane D."
+func reviewAuthorName(user *models.User) string {
+	if user.LastName == "" {
+		return user.FirstName
+	}
+
+	return fmt.Sprintf("%s %c.", user.FirstName, []rune(user.LastName)[0])
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+)
+
+func TestCreateReviewAlreadyReviewed(t *testing.T) {
+	tests := []struct {
+		name string
+		// existing is the number of reviews the fast path finds
+		existing int
+		// insertErr is the error creating the review fails with
+		insertErr error
+	}{
+		{name: "found before creating", existing: 1},
+		{
+			name:      "created by a parallel request",
+			insertErr: &pgconn.PgError{Code: uniqueViolation, ConstraintName: reviewPerUserIndex},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			s := NewReviewService(db, nil)
+
+			mock.ExpectQuery(`SELECT \* FROM "products"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Headphones"))
+			mock.ExpectQuery(`SELECT \* FROM "users"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "buyer@example.com"))
+			mock.ExpectQuery(`SELECT count\(\*\) FROM "reviews"`).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.existing))
+			if tt.insertErr != nil {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "order_items"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "reviews"`).WillReturnError(tt.insertErr)
+				mock.ExpectRollback()
+			}
+
+			_, err := s.CreateReview(1, 1, &dto.CreateReviewRequest{Rating: 5, Body: "Great"})
+			if !errors.Is(err, ErrAlreadyReviewed) {
+				t.Errorf("CreateReview() error = %v, want %v", err, ErrAlreadyReviewed)
+			}
+		})
+	}
+}
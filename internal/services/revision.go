@@ -0,0 +1,274 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	RevisionEntityProduct  = "product"
+	RevisionEntityCategory = "category"
+)
+
+// productState is the part of a product tracked by revisions. The stock level
+// is left out on purpose: it changes with every order and is not an editorial
+// field. How the product is sold once out of stock is tracked.
+type productState struct {
+	CategoryID        uint              `json:"category_id"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	Price             money.Money       `json:"price"`
+	SKU               string            `json:"sku"`
+	IsActive          bool              `json:"is_active"`
+	IsDigital         bool              `json:"is_digital"`
+	IsBundle          bool              `json:"is_bundle"`
+	BundlePricing     string            `json:"bundle_pricing"`
+	BundlePercentage  float64           `json:"bundle_percentage"`
+	BundleItems       []bundleItemState `json:"bundle_items"`
+	StockPolicy       string            `json:"stock_policy"`
+	BackorderLimit    int               `json:"backorder_limit"`
+	ExpectedShipDate  *time.Time        `json:"expected_ship_date"`
+	LowStockThreshold *int              `json:"low_stock_threshold"`
+}
+
+type bundleItemState struct {
+	ComponentID uint `json:"component_id"`
+	Quantity    int  `json:"quantity"`
+}
+
+type categoryState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsActive    bool   `json:"is_active"`
+}
+
+type fieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// lockEntity locks the row of an entity, including a trashed one, so its
+// revisions are recorded one at a time
+func lockEntity(tx *gorm.DB, entityType string, entityID uint) error {
+	var entity interface{}
+
+	switch entityType {
+	case RevisionEntityProduct:
+		entity = &models.Product{}
+	case RevisionEntityCategory:
+		entity = &models.Category{}
+	default:
+		return errors.New("unknown revision entity type")
+	}
+
+	return tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(entity, entityID).Error
+}
+
+// loadState returns the tracked fields of an entity, including trashed ones
+func loadState(tx *gorm.DB, entityType string, entityID uint) (map[string]interface{}, error) {
+	var state interface{}
+
+	switch entityType {
+	case RevisionEntityProduct:
+		var product models.Product
+		if err := tx.Unscoped().Preload("BundleItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("component_id")
+		}).First(&product, entityID).Error; err != nil {
+			return nil, err
+		}
+
+		items := make([]bundleItemState, len(product.BundleItems))
+		for i := range product.BundleItems {
+			items[i] = bundleItemState{
+				ComponentID: product.BundleItems[i].ComponentID,
+				Quantity:    product.BundleItems[i].Quantity,
+			}
+		}
+
+		state = productState{
+			CategoryID:        product.CategoryID,
+			Name:              product.Name,
+			Description:       product.Description,
+			Price:             product.Price,
+			SKU:               product.SKU,
+			IsActive:          product.IsActive,
+			IsDigital:         product.IsDigital,
+			IsBundle:          product.IsBundle,
+			BundlePricing:     string(product.BundlePricing),
+			BundlePercentage:  product.BundlePercentage,
+			BundleItems:       items,
+			StockPolicy:       string(product.StockPolicy),
+			BackorderLimit:    product.BackorderLimit,
+			ExpectedShipDate:  product.ExpectedShipDate,
+			LowStockThreshold: product.LowStockThreshold,
+		}
+	case RevisionEntityCategory:
+		var category models.Category
+		if err := tx.Unscoped().First(&category, entityID).Error; err != nil {
+			return nil, err
+		}
+
+		state = categoryState{
+			Name:        category.Name,
+			Description: category.Description,
+			IsActive:    category.IsActive,
+		}
+	default:
+		return nil, errors.New("unknown revision entity type")
+	}
+
+	// Round trip through JSON so states compare the same way they are stored
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// trackRevision runs fn in a transaction and records the change it makes to
+// the entity as a revision. Updates that change no tracked field are not
+// recorded.
+func trackRevision(db *gorm.DB, actorID uint, entityType string, entityID uint, action models.RevisionAction, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := lockEntity(tx, entityType, entityID); err != nil {
+			return err
+		}
+
+		before, err := loadState(tx, entityType, entityID)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		return recordRevision(tx, actorID, entityType, entityID, action, before, nil)
+	})
+}
+
+// recordRevision stores the diff between before and the current state of the
+// entity. before is nil for newly created entities. The entity is locked until
+// the transaction ends, so concurrent changes can't take the same version.
+func recordRevision(tx *gorm.DB, actorID uint, entityType string, entityID uint, action models.RevisionAction, before map[string]interface{}, rollbackOf *uint) error {
+	if err := lockEntity(tx, entityType, entityID); err != nil {
+		return err
+	}
+
+	after, err := loadState(tx, entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	changes := diffStates(before, after)
+	if action == models.RevisionActionUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	snapshotJSON, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	var version int
+	if err := tx.Model(&models.Revision{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).Error; err != nil {
+		return err
+	}
+
+	revision := models.Revision{
+		EntityType:   entityType,
+		EntityID:     entityID,
+		Version:      version + 1,
+		Action:       action,
+		RollbackOfID: rollbackOf,
+		Changes:      changesJSON,
+		Snapshot:     snapshotJSON,
+	}
+
+	if actorID != 0 {
+		revision.ActorID = &actorID
+	}
+
+	return tx.Create(&revision).Error
+}
+
+func diffStates(before, after map[string]interface{}) map[string]fieldChange {
+	changes := make(map[string]fieldChange)
+	for field, to := range after {
+		from, ok := before[field]
+		if ok && reflect.DeepEqual(from, to) {
+			continue
+		}
+		changes[field] = fieldChange{From: from, To: to}
+	}
+
+	return changes
+}
+
+func convertToRevisionResponse(revision *models.Revision) dto.RevisionResponse {
+	var changes map[string]fieldChange
+	_ = json.Unmarshal(revision.Changes, &changes)
+
+	var snapshot map[string]interface{}
+	_ = json.Unmarshal(revision.Snapshot, &snapshot)
+
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	changeList := make([]dto.FieldChange, len(fields))
+	for i, field := range fields {
+		changeList[i] = dto.FieldChange{
+			Field: field,
+			From:  changes[field].From,
+			To:    changes[field].To,
+		}
+	}
+
+	response := dto.RevisionResponse{
+		ID:           revision.ID,
+		EntityType:   revision.EntityType,
+		EntityID:     revision.EntityID,
+		Version:      revision.Version,
+		Action:       string(revision.Action),
+		ActorID:      revision.ActorID,
+		RollbackOfID: revision.RollbackOfID,
+		Changes:      changeList,
+		Snapshot:     snapshot,
+		CreatedAt:    revision.CreatedAt,
+	}
+
+	if revision.Actor != nil {
+		response.ActorEmail = revision.Actor.Email
+	}
+
+	return response
+}
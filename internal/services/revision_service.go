@@ -0,0 +1,202 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+var _ RevisionServiceInterface = (*RevisionService)(nil)
+
+type RevisionService struct {
+	db *gorm.DB
+}
+
+func NewRevisionService(db *gorm.DB) *RevisionService {
+	return &RevisionService{db: db}
+}
+
+func (s *RevisionService) GetRevisions(entityType string, entityID uint, page, limit int) ([]dto.RevisionResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	query := s.db.Model(&models.Revision{}).Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+
+	var total int64
+	query.Count(&total)
+
+	var revisions []models.Revision
+	if err := query.Preload("Actor").
+		Order("version DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&revisions).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.RevisionResponse, len(revisions))
+	for i := range revisions {
+		response[i] = convertToRevisionResponse(&revisions[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+func (s *RevisionService) GetRevision(id uint) (*dto.RevisionResponse, error) {
+	var revision models.Revision
+	if err := s.db.Preload("Actor").First(&revision, id).Error; err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	response := convertToRevisionResponse(&revision)
+	return &response, nil
+}
+
+// Rollback restores the tracked fields of an entity to the snapshot of the
+// given revision. The rollback itself is recorded as a new revision, so it can
+// be undone the same way.
+func (s *RevisionService) Rollback(actorID, revisionID uint) (*dto.RevisionResponse, error) {
+	var target models.Revision
+	if err := s.db.First(&target, revisionID).Error; err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	if target.Action == models.RevisionActionDelete {
+		return nil, errors.New("cannot roll back to a delete, restore the item from the trash instead")
+	}
+
+	var applied models.Revision
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockEntity(tx, target.EntityType, target.EntityID); err != nil {
+			return err
+		}
+
+		before, err := loadState(tx, target.EntityType, target.EntityID)
+		if err != nil {
+			return err
+		}
+
+		switch target.EntityType {
+		case RevisionEntityProduct:
+			err = applyProductSnapshot(tx, target.EntityID, target.Snapshot)
+		case RevisionEntityCategory:
+			err = applyCategorySnapshot(tx, target.EntityID, target.Snapshot)
+		default:
+			err = fmt.Errorf("unknown revision entity type: %s", target.EntityType)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := recordRevision(tx, actorID, target.EntityType, target.EntityID, models.RevisionActionRollback, before, &target.ID); err != nil {
+			return err
+		}
+
+		return tx.Where("entity_type = ? AND entity_id = ?", target.EntityType, target.EntityID).
+			Order("version DESC").
+			First(&applied).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRevision(applied.ID)
+}
+
+func applyProductSnapshot(tx *gorm.DB, productID uint, snapshot json.RawMessage) error {
+	var state productState
+	if err := json.Unmarshal(snapshot, &state); err != nil {
+		return err
+	}
+
+	var product models.Product
+	if err := tx.First(&product, productID).Error; err != nil {
+		return errors.New("product is in the trash, restore it before rolling back")
+	}
+
+	var category models.Category
+	if err := tx.First(&category, state.CategoryID).Error; err != nil {
+		return errors.New("the revision's category no longer exists")
+	}
+
+	if err := tx.Where("bundle_id = ?", productID).Delete(&models.BundleItem{}).Error; err != nil {
+		return err
+	}
+
+	if len(state.BundleItems) > 0 {
+		items := make([]models.BundleItem, len(state.BundleItems))
+		for i, item := range state.BundleItems {
+			items[i] = models.BundleItem{
+				BundleID:    productID,
+				ComponentID: item.ComponentID,
+				Quantity:    item.Quantity,
+			}
+		}
+
+		if err := tx.Create(&items).Error; err != nil {
+			return fmt.Errorf("unable to restore bundle components: %w", err)
+		}
+	}
+
+	updates := map[string]interface{}{
+		"category_id":       state.CategoryID,
+		"name":              state.Name,
+		"description":       state.Description,
+		"price":             state.Price,
+		"sku":               state.SKU,
+		"is_active":         state.IsActive,
+		"is_digital":        state.IsDigital,
+		"is_bundle":         state.IsBundle,
+		"bundle_pricing":    state.BundlePricing,
+		"bundle_percentage": state.BundlePercentage,
+	}
+
+	// Snapshots from before the stock settings were tracked leave the
+	// current ones as they are
+	if state.StockPolicy != "" {
+		updates["stock_policy"] = state.StockPolicy
+		updates["backorder_limit"] = state.BackorderLimit
+		updates["expected_ship_date"] = state.ExpectedShipDate
+		updates["low_stock_threshold"] = state.LowStockThreshold
+	}
+
+	return tx.Model(&product).Updates(updates).Error
+}
+
+func applyCategorySnapshot(tx *gorm.DB, categoryID uint, snapshot json.RawMessage) error {
+	var state categoryState
+	if err := json.Unmarshal(snapshot, &state); err != nil {
+		return err
+	}
+
+	var category models.Category
+	if err := tx.First(&category, categoryID).Error; err != nil {
+		return errors.New("category is in the trash, restore it before rolling back")
+	}
+
+	return tx.Model(&category).Updates(map[string]interface{}{
+		"name":        state.Name,
+		"description": state.Description,
+		"is_active":   state.IsActive,
+	}).Error
+}
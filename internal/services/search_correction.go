@@ -0,0 +1,67 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minCorrectedWordLength is the shortest word corrected, in characters. Shorter
+// words have too few trigrams to find a meaningful match.
+const minCorrectedWordLength = 3
+
+// correctSearchQuery replaces each word of a query that isn't in the catalog
+// vocabulary with the most similar word that is, preferring the more common
+// one. It returns an empty string when no word needed correcting.
+//
+// Words are similar when pg_trgm's % operator says so, by the
+// pg_trgm.similarity_threshold database.New sets for each connection.
+func (s *ProductService) correctSearchQuery(query string) (string, error) {
+	words := searchWords(normalizeSearchQuery(query))
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	// Short words and numbers, such as model numbers, are kept as typed.
+	// Search words are only letters and digits, so any other character is a
+	// letter.
+	var corrected []string
+	if err := s.db.Raw(`
+		SELECT COALESCE(v.word, w.word)
+		FROM unnest(string_to_array(@words, ' ')) WITH ORDINALITY AS w(word, position)
+		LEFT JOIN LATERAL (
+			SELECT word
+			FROM search_vocabulary
+			WHERE char_length(w.word) >= @min_length
+				AND w.word ~ '[^0-9]'
+				AND word % w.word
+			ORDER BY similarity(word, w.word) DESC, ndoc DESC, word
+			LIMIT 1
+		) v ON true
+		ORDER BY w.position`, map[string]interface{}{
+		"words":      strings.Join(words, " "),
+		"min_length": minCorrectedWordLength,
+	}).Scan(&corrected).Error; err != nil {
+		return "", err
+	}
+
+	suggestion := strings.Join(corrected, " ")
+	if suggestion == strings.Join(words, " ") {
+		return "", nil
+	}
+
+	return suggestion, nil
+}
+
+// RefreshSearchVocabulary rebuilds the catalog vocabulary from the current
+// product and category names. Searches keep using the previous one until it
+// is done.
+func (s *ProductService) RefreshSearchVocabulary() error {
+	return s.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY search_vocabulary").Error
+}
+
+// searchWords splits a query into its words of letters and digits
+func searchWords(query string) []string {
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
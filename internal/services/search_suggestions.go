@@ -0,0 +1,184 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// defaultSuggestionLimit is the number of suggestions of each kind when
+	// the request doesn't ask for a number
+	defaultSuggestionLimit = 5
+	// maxSuggestionLimit bounds the number of suggestions of each kind
+	maxSuggestionLimit = 20
+	// maxSearchQueryLength is the longest query recorded for suggestions, in
+	// characters
+	maxSearchQueryLength = 100
+)
+
+// likeEscaper escapes the LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SuggestSearch returns the products, categories and popular searches that
+// match a partial query. Product names match when every word of the query
+// starts a word of the name, so "wirel head" suggests "Wireless Headphones",
+// and names and searches also match by trigram similarity, so misspellings
+// still get suggestions.
+func (s *ProductService) SuggestSearch(req *dto.SearchSuggestRequest) (*dto.SearchSuggestionsResponse, error) {
+	limit := req.Limit
+	if limit < 1 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	locale := req.Localization.Locale
+	if locale == "" {
+		locale = models.DefaultLocale
+	}
+
+	response := &dto.SearchSuggestionsResponse{
+		Products:   []dto.SearchSuggestion{},
+		Categories: []dto.SearchSuggestion{},
+		Queries:    []string{},
+	}
+
+	query := normalizeSearchQuery(req.Query)
+	prefix := prefixSearchQuery(query)
+	if prefix == "" {
+		return response, nil
+	}
+
+	args := map[string]interface{}{
+		"locale":         locale,
+		"default_locale": models.DefaultLocale,
+		"query":          query,
+		"prefix":         prefix,
+		"contains":       "%" + likeEscaper.Replace(query) + "%",
+		"starts":         likeEscaper.Replace(query) + "%",
+		"limit":          limit,
+	}
+
+	// Products translated into the locale are suggested by their translated
+	// name, the rest by the default one. The name-only prefix query uses the
+	// search vectors and the similarity match the trigram indexes.
+	if err := s.db.Raw(`
+		SELECT id, name FROM (
+			SELECT p.id, pt.name
+			FROM product_translations pt
+			JOIN products p ON p.id = pt.product_id
+			WHERE pt.locale = @locale
+				AND p.deleted_at IS NULL AND p.is_active = true
+				AND (pt.search_vector @@ to_tsquery(locale_search_config(@locale), @prefix)
+					OR @query <% lower(pt.name))
+			UNION ALL
+			SELECT p.id, p.name
+			FROM products p
+			WHERE p.deleted_at IS NULL AND p.is_active = true
+				AND (p.search_vector @@ to_tsquery(locale_search_config(@default_locale), @prefix)
+					OR @query <% lower(p.name))
+				AND NOT EXISTS (
+					SELECT 1 FROM product_translations pt
+					WHERE pt.product_id = p.id AND pt.locale = @locale
+				)
+		) suggestions
+		ORDER BY starts_with(lower(name), @query) DESC, word_similarity(@query, lower(name)) DESC, name
+		LIMIT @limit`, args).
+		Scan(&response.Products).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Raw(`
+		SELECT id, name FROM (
+			SELECT c.id, ct.name
+			FROM category_translations ct
+			JOIN categories c ON c.id = ct.category_id
+			WHERE ct.locale = @locale
+				AND c.deleted_at IS NULL AND c.is_active = true
+				AND (lower(ct.name) LIKE @contains OR @query <% lower(ct.name))
+			UNION ALL
+			SELECT c.id, c.name
+			FROM categories c
+			WHERE c.deleted_at IS NULL AND c.is_active = true
+				AND (lower(c.name) LIKE @contains OR @query <% lower(c.name))
+				AND NOT EXISTS (
+					SELECT 1 FROM category_translations ct
+					WHERE ct.category_id = c.id AND ct.locale = @locale
+				)
+		) suggestions
+		ORDER BY starts_with(lower(name), @query) DESC, word_similarity(@query, lower(name)) DESC, name
+		LIMIT @limit`, args).
+		Scan(&response.Categories).Error; err != nil {
+		return nil, err
+	}
+
+	// Searches that start with the query come first, then similar ones, each
+	// from the most searched
+	if err := s.db.Raw(`
+		SELECT query
+		FROM search_queries
+		WHERE locale = @locale AND (query LIKE @starts OR @query <% query)
+		ORDER BY query LIKE @starts DESC, search_count DESC, query
+		LIMIT @limit`, args).
+		Scan(&response.Queries).Error; err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// recordSearchQuery counts a search that found products towards the popular
+// searches suggested in its locale
+func recordSearchQuery(db *gorm.DB, locale, query string, results int64) error {
+	query = normalizeSearchQuery(query)
+	if query == "" {
+		return nil
+	}
+
+	now := time.Now()
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "locale"}, {Name: "query"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"search_count":     gorm.Expr("search_queries.search_count + 1"),
+			"result_count":     results,
+			"last_searched_at": now,
+		}),
+	}).Create(&models.SearchQuery{
+		Locale:         locale,
+		Query:          query,
+		SearchCount:    1,
+		ResultCount:    int(results),
+		LastSearchedAt: now,
+	}).Error
+}
+
+// normalizeSearchQuery lowercases a query and collapses its whitespace, so
+// the same search typed differently is counted once
+func normalizeSearchQuery(query string) string {
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if runes := []rune(query); len(runes) > maxSearchQueryLength {
+		query = strings.TrimSpace(string(runes[:maxSearchQueryLength]))
+	}
+
+	return query
+}
+
+// prefixSearchQuery builds a tsquery matching names with a word starting with
+// each word of the query. Only letters and digits are kept, so the result is
+// always valid tsquery syntax.
+func prefixSearchQuery(query string) string {
+	words := searchWords(query)
+	terms := make([]string, len(words))
+	for i, word := range words {
+		// A is the weight of the name in the search vectors
+		terms[i] = word + ":*A"
+	}
+
+	return strings.Join(terms, " & ")
+}
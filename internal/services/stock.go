@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"math"
+
+	"github.com/joefazee/learning-go-shop/internal/models"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// onHandStock returns how many units of a product are physically in stock. A
+// bundle has no stock of its own; it is limited by its scarcest component.
+func onHandStock(product *models.Product) int {
+	return bundleStock(product, func(component *models.Product) int {
+		return component.Stock
+	})
+}
+
+// availableStock returns how many units of a product can be sold: the on-hand
+// stock less the units reserved by checkouts. held are the units reserved by
+// the customer asking, which remain available to them.
+func availableStock(product *models.Product, held map[uint]int) int {
+	return bundleStock(product, func(component *models.Product) int {
+		return component.Stock - component.Reserved + held[component.ID]
+	})
+}
+
+func bundleStock(product *models.Product, stock func(*models.Product) int) int {
+	if !product.IsBundle {
+		return max(stock(product), 0)
+	}
+
+	if len(product.BundleItems) == 0 {
+		return 0
+	}
+
+	available := math.MaxInt
+	for i := range product.BundleItems {
+		item := &product.BundleItems[i]
+		if n := stock(&item.Component) / item.Quantity; n < available {
+			available = n
+		}
+	}
+
+	return max(available, 0)
+}
+
+// Availability of a product, as shown to customers
+const (
+	AvailabilityInStock    = "in_stock"
+	AvailabilityBackorder  = "backorder"
+	AvailabilityPreorder   = "preorder"
+	AvailabilityOutOfStock = "out_of_stock"
+)
+
+// stockPolicy returns how a product sells beyond its stock. Bundles and
+// digital products only sell what is in stock.
+func stockPolicy(product *models.Product) models.StockPolicy {
+	if product.IsBundle || product.IsDigital || product.StockPolicy == "" {
+		return models.StockPolicyDeny
+	}
+
+	return product.StockPolicy
+}
+
+// backorderable returns how many more units of a product can be backordered
+func backorderable(product *models.Product) int {
+	if stockPolicy(product) == models.StockPolicyDeny {
+		return 0
+	}
+
+	if product.BackorderLimit == 0 {
+		return math.MaxInt
+	}
+
+	return max(product.BackorderLimit-product.Backordered, 0)
+}
+
+// hasStock reports whether quantity units of product can be sold to a customer
+// holding the given reservations, from stock or on backorder. Digital products
+// are never out of stock.
+func hasStock(product *models.Product, quantity int, held map[uint]int) bool {
+	if product.IsDigital {
+		return true
+	}
+
+	return quantity-inStockQuantity(product, quantity, held) <= backorderable(product)
+}
+
+// inStockQuantity returns how many of quantity units of product are taken from
+// stock for a customer holding the given reservations. The rest are
+// backordered, or can't be sold when the product only sells what is in stock.
+func inStockQuantity(product *models.Product, quantity int, held map[uint]int) int {
+	if product.IsDigital {
+		return quantity
+	}
+
+	if stockPolicy(product) == models.StockPolicyPreorder {
+		return 0
+	}
+
+	return min(quantity, availableStock(product, held))
+}
+
+// stockAvailability describes how a product can be bought right now
+func stockAvailability(product *models.Product) string {
+	switch {
+	case product.IsDigital:
+		return AvailabilityInStock
+	case stockPolicy(product) == models.StockPolicyPreorder && backorderable(product) > 0:
+		return AvailabilityPreorder
+	case availableStock(product, nil) > 0:
+		return AvailabilityInStock
+	case backorderable(product) > 0:
+		return AvailabilityBackorder
+	default:
+		return AvailabilityOutOfStock
+	}
+}
+
+// stockRequirements returns the units to take from each product's stock when
+// selling quantity of product.
+func stockRequirements(product *models.Product, quantity int) map[uint]int {
+	if product.IsDigital || quantity <= 0 {
+		return nil
+	}
+
+	if !product.IsBundle {
+		return map[uint]int{product.ID: quantity}
+	}
+
+	required := make(map[uint]int, len(product.BundleItems))
+	for i := range product.BundleItems {
+		item := &product.BundleItems[i]
+		required[item.ComponentID] += item.Quantity * quantity
+	}
+
+	return required
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"log"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/events"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/notifications"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrProductInStock            = errors.New("product is in stock")
+	ErrStockSubscriptionNotFound = errors.New("stock subscription not found")
+)
+
+var _ StockSubscriptionServiceInterface = (*StockSubscriptionService)(nil)
+
+type StockSubscriptionService struct {
+	db *gorm.DB
+}
+
+func NewStockSubscriptionService(db *gorm.DB) *StockSubscriptionService {
+	return &StockSubscriptionService{db: db}
+}
+
+// Subscribe asks for the user to be emailed when an out of stock product is
+// back in stock. Subscribing again has no effect.
+func (s *StockSubscriptionService) Subscribe(userID, productID uint) (*dto.StockSubscriptionResponse, error) {
+	var product models.Product
+	if err := s.db.Preload("BundleItems.Component").
+		Where("is_active = ?", true).
+		First(&product, productID).Error; err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	// Products that can only be backordered are out of stock too, so this
+	// looks at the stock itself rather than whether the product can be sold
+	if product.IsDigital || availableStock(&product, nil) > 0 {
+		return nil, ErrProductInStock
+	}
+
+	subscription := models.StockSubscription{UserID: userID, ProductID: productID}
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&subscription).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Preload("Product").
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&subscription).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToStockSubscriptionResponse(&subscription)
+	return &response, nil
+}
+
+// GetSubscriptions returns the products the user is waiting on, newest first
+func (s *StockSubscriptionService) GetSubscriptions(userID uint) ([]dto.StockSubscriptionResponse, error) {
+	var subscriptions []models.StockSubscription
+	if err := s.db.Preload("Product").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.StockSubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		response[i] = convertToStockSubscriptionResponse(&subscriptions[i])
+	}
+
+	return response, nil
+}
+
+func (s *StockSubscriptionService) CancelSubscription(userID, id uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.StockSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrStockSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// backInStock returns the products, including bundles of the product, that
+// came back in stock when delta units of productID were added, with their
+// subscribers in batches of at most batchSize. The delta must already be
+// applied. The subscriptions are removed, since their subscribers are about
+// to be notified.
+func backInStock(tx *gorm.DB, productID uint, delta, batchSize int) ([]notifications.BackInStockPayload, error) {
+	if delta <= 0 {
+		return nil, nil
+	}
+
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	var products []models.Product
+	if err := tx.Preload("BundleItems.Component").
+		Where("id = ? OR id IN (?)", productID,
+			tx.Model(&models.BundleItem{}).Select("bundle_id").Where("component_id = ?", productID)).
+		Where("id IN (?)", tx.Model(&models.StockSubscription{}).Select("product_id")).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	var payloads []notifications.BackInStockPayload
+	for i := range products {
+		product := &products[i]
+		before := bundleStock(product, func(component *models.Product) int {
+			if component.ID == productID {
+				return component.Stock - delta
+			}
+			return component.Stock
+		})
+
+		if before > 0 || onHandStock(product) == 0 {
+			continue
+		}
+
+		var subscriptions []models.StockSubscription
+		if err := tx.Clauses(clause.Returning{}).
+			Where("product_id = ?", product.ID).
+			Delete(&subscriptions).Error; err != nil {
+			return nil, err
+		}
+
+		userIDs := make([]uint, len(subscriptions))
+		for j := range subscriptions {
+			userIDs[j] = subscriptions[j].UserID
+		}
+
+		var users []models.User
+		if err := tx.Where("id IN ?", userIDs).Order("id").Find(&users).Error; err != nil {
+			return nil, err
+		}
+
+		for start := 0; start < len(users); start += batchSize {
+			batch := users[start:min(start+batchSize, len(users))]
+			payload := notifications.BackInStockPayload{
+				ProductID:   product.ID,
+				ProductName: product.Name,
+				Subscribers: make([]notifications.Subscriber, len(batch)),
+			}
+			for j := range batch {
+				payload.Subscribers[j] = notifications.Subscriber{
+					Email:     batch[j].Email,
+					FirstName: batch[j].FirstName,
+					LastName:  batch[j].LastName,
+				}
+			}
+			payloads = append(payloads, payload)
+		}
+	}
+
+	return payloads, nil
+}
+
+// publishBackInStock asks the notifier to email the subscribers of products
+// back in stock. The restock is already committed, so failures are only logged.
+func publishBackInStock(publisher events.Publisher, payloads []notifications.BackInStockPayload) {
+	for i := range payloads {
+		if err := publisher.Publish(notifications.ProductBackInStock, payloads[i], map[string]string{}); err != nil {
+			log.Printf("unable to publish back in stock event for product %d: %v", payloads[i].ProductID, err)
+		}
+	}
+}
+
+func convertToStockSubscriptionResponse(subscription *models.StockSubscription) dto.StockSubscriptionResponse {
+	return dto.StockSubscriptionResponse{
+		ID:          subscription.ID,
+		ProductID:   subscription.ProductID,
+		ProductName: subscription.Product.Name,
+		SKU:         subscription.Product.SKU,
+		CreatedAt:   subscription.CreatedAt,
+	}
+}
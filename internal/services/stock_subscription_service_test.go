@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSubscribe(t *testing.T) {
+	productColumns := []string{"id", "name", "stock", "reserved", "stock_policy", "is_active"}
+
+	t.Run("out of stock", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectQuery(`SELECT \* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows(productColumns).AddRow(1, "Lamp", 0, 0, "deny", true))
+		mock.ExpectQuery(`SELECT \* FROM "bundle_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "stock_subscriptions" .* ON CONFLICT DO NOTHING`).
+			WithArgs(uint(7), uint(1), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectCommit()
+		mock.ExpectQuery(`SELECT \* FROM "stock_subscriptions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "product_id", "created_at"}).AddRow(3, 7, 1, time.Now()))
+		mock.ExpectQuery(`SELECT \* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows(productColumns).AddRow(1, "Lamp", 0, 0, "deny", true))
+
+		subscription, err := NewStockSubscriptionService(db).Subscribe(7, 1)
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+		if subscription.ProductID != 1 {
+			t.Errorf("Subscribe() product = %d, want 1", subscription.ProductID)
+		}
+	})
+
+	t.Run("in stock", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectQuery(`SELECT \* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows(productColumns).AddRow(1, "Lamp", 2, 0, "deny", true))
+		mock.ExpectQuery(`SELECT \* FROM "bundle_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := NewStockSubscriptionService(db).Subscribe(7, 1)
+		if !errors.Is(err, ErrProductInStock) {
+			t.Errorf("Subscribe() error = %v, want %v", err, ErrProductInStock)
+		}
+	})
+}
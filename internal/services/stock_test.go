@@ -0,0 +1,108 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/joefazee/learning-go-shop/internal/models"
+)
+
+func TestHasStock(t *testing.T) {
+	tests := []struct {
+		name     string
+		product  models.Product
+		quantity int
+		held     map[uint]int
+		want     bool
+		inStock  int
+	}{
+		{
+			name:     "deny within stock",
+			product:  models.Product{ID: 1, Stock: 5, StockPolicy: models.StockPolicyDeny},
+			quantity: 5,
+			want:     true,
+			inStock:  5,
+		},
+		{
+			name:     "deny beyond stock",
+			product:  models.Product{ID: 1, Stock: 5, StockPolicy: models.StockPolicyDeny},
+			quantity: 6,
+			want:     false,
+			inStock:  5,
+		},
+		{
+			name:     "deny out of stock",
+			product:  models.Product{ID: 1, StockPolicy: models.StockPolicyDeny},
+			quantity: 1,
+			want:     false,
+			inStock:  0,
+		},
+		{
+			name:     "deny with stock reserved by others",
+			product:  models.Product{ID: 1, Stock: 5, Reserved: 4},
+			quantity: 2,
+			want:     false,
+			inStock:  1,
+		},
+		{
+			name:     "deny with stock held by the customer",
+			product:  models.Product{ID: 1, Stock: 5, Reserved: 4},
+			quantity: 2,
+			held:     map[uint]int{1: 2},
+			want:     true,
+			inStock:  2,
+		},
+		{
+			name:     "backorder within limit",
+			product:  models.Product{ID: 1, Stock: 2, StockPolicy: models.StockPolicyBackorder, BackorderLimit: 3},
+			quantity: 5,
+			want:     true,
+			inStock:  2,
+		},
+		{
+			name:     "backorder beyond limit",
+			product:  models.Product{ID: 1, Stock: 2, StockPolicy: models.StockPolicyBackorder, BackorderLimit: 3, Backordered: 1},
+			quantity: 5,
+			want:     false,
+			inStock:  2,
+		},
+		{
+			name:     "backorder without limit",
+			product:  models.Product{ID: 1, StockPolicy: models.StockPolicyBackorder},
+			quantity: 100,
+			want:     true,
+			inStock:  0,
+		},
+		{
+			name:     "preorder within limit",
+			product:  models.Product{ID: 1, Stock: 10, StockPolicy: models.StockPolicyPreorder, BackorderLimit: 3},
+			quantity: 3,
+			want:     true,
+			inStock:  0,
+		},
+		{
+			name:     "preorder beyond limit",
+			product:  models.Product{ID: 1, Stock: 10, StockPolicy: models.StockPolicyPreorder, BackorderLimit: 3},
+			quantity: 4,
+			want:     false,
+			inStock:  0,
+		},
+		{
+			name:     "digital",
+			product:  models.Product{ID: 1, IsDigital: true},
+			quantity: 3,
+			want:     true,
+			inStock:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasStock(&tt.product, tt.quantity, tt.held); got != tt.want {
+				t.Errorf("hasStock() = %v, want %v", got, tt.want)
+			}
+			if got := inStockQuantity(&tt.product, tt.quantity, tt.held); got != tt.inStock {
+				t.Errorf("inStockQuantity() = %d, want %d", got, tt.inStock)
+			}
+		})
+	}
+}
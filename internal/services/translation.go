@@ -0,0 +1,53 @@
+package services
+
+import (
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+)
+
+// productText returns the name and description of a product in the locale of
+// its loaded translation. Without one, or for fields the translation leaves
+// empty, the default locale's content is used.
+func productText(product *models.Product) (name, description string) {
+	name, description = product.Name, product.Description
+	if len(product.Translations) == 0 {
+		return name, description
+	}
+
+	translation := &product.Translations[0]
+	name = translation.Name
+	if translation.Description != "" {
+		description = translation.Description
+	}
+
+	return name, description
+}
+
+// categoryText is productText for categories
+func categoryText(category *models.Category) (name, description string) {
+	name, description = category.Name, category.Description
+	if len(category.Translations) == 0 {
+		return name, description
+	}
+
+	translation := &category.Translations[0]
+	name = translation.Name
+	if translation.Description != "" {
+		description = translation.Description
+	}
+
+	return name, description
+}
+
+func convertToCategoryResponse(category *models.Category) dto.CategoryResponse {
+	name, description := categoryText(category)
+
+	return dto.CategoryResponse{
+		ID:          category.ID,
+		Name:        name,
+		Description: description,
+		IsActive:    category.IsActive,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
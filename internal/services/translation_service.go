@@ -0,0 +1,213 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnsupportedLocale   = errors.New("locale is not supported")
+	ErrDefaultLocale       = errors.New("default locale content is edited on the product or category itself")
+	ErrTranslationNotFound = errors.New("translation not found")
+)
+
+var _ TranslationServiceInterface = (*TranslationService)(nil)
+
+type TranslationService struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+func NewTranslationService(db *gorm.DB, config *config.Config) *TranslationService {
+	return &TranslationService{db: db, config: config}
+}
+
+// ResolveLocale normalizes a requested locale. An empty locale resolves to the
+// default locale; any other locale has to be configured.
+func (s *TranslationService) ResolveLocale(locale string) (string, error) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "" {
+		return models.DefaultLocale, nil
+	}
+
+	if !slices.Contains(s.config.Store.Locales, locale) {
+		return "", ErrUnsupportedLocale
+	}
+
+	return locale, nil
+}
+
+func (s *TranslationService) GetProductTranslations(productID uint) ([]dto.TranslationResponse, error) {
+	var translations []models.ProductTranslation
+	if err := s.db.Where("product_id = ?", productID).Order("locale").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.TranslationResponse, len(translations))
+	for i := range translations {
+		response[i] = convertToProductTranslationResponse(&translations[i])
+	}
+
+	return response, nil
+}
+
+// SetProductTranslation creates or replaces the translation of a product into
+// locale. Its search vector is maintained by the database.
+func (s *TranslationService) SetProductTranslation(productID uint, locale string, req *dto.SetTranslationRequest) (*dto.TranslationResponse, error) {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	translation := models.ProductTranslation{
+		ProductID:   productID,
+		Locale:      locale,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+	}).Create(&translation).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Where("product_id = ? AND locale = ?", productID, locale).First(&translation).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToProductTranslationResponse(&translation)
+	return &response, nil
+}
+
+func (s *TranslationService) DeleteProductTranslation(productID uint, locale string) error {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Where("product_id = ? AND locale = ?", productID, locale).Delete(&models.ProductTranslation{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+func (s *TranslationService) GetCategoryTranslations(categoryID uint) ([]dto.TranslationResponse, error) {
+	var translations []models.CategoryTranslation
+	if err := s.db.Where("category_id = ?", categoryID).Order("locale").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.TranslationResponse, len(translations))
+	for i := range translations {
+		response[i] = convertToCategoryTranslationResponse(&translations[i])
+	}
+
+	return response, nil
+}
+
+func (s *TranslationService) SetCategoryTranslation(categoryID uint, locale string, req *dto.SetTranslationRequest) (*dto.TranslationResponse, error) {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var category models.Category
+	if err := s.db.First(&category, categoryID).Error; err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	translation := models.CategoryTranslation{
+		CategoryID:  categoryID,
+		Locale:      locale,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "category_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+	}).Create(&translation).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Where("category_id = ? AND locale = ?", categoryID, locale).First(&translation).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToCategoryTranslationResponse(&translation)
+	return &response, nil
+}
+
+func (s *TranslationService) DeleteCategoryTranslation(categoryID uint, locale string) error {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Where("category_id = ? AND locale = ?", categoryID, locale).Delete(&models.CategoryTranslation{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+// translationLocale normalizes a locale that content can be translated into
+func (s *TranslationService) translationLocale(locale string) (string, error) {
+	if strings.TrimSpace(locale) == "" {
+		return "", ErrUnsupportedLocale
+	}
+
+	locale, err := s.ResolveLocale(locale)
+	if err != nil {
+		return "", err
+	}
+
+	if locale == models.DefaultLocale {
+		return "", ErrDefaultLocale
+	}
+
+	return locale, nil
+}
+
+func convertToProductTranslationResponse(translation *models.ProductTranslation) dto.TranslationResponse {
+	return dto.TranslationResponse{
+		Locale:      translation.Locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+		UpdatedAt:   translation.UpdatedAt,
+	}
+}
+
+func convertToCategoryTranslationResponse(translation *models.CategoryTranslation) dto.TranslationResponse {
+	return dto.TranslationResponse{
+		Locale:      translation.Locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+		UpdatedAt:   translation.UpdatedAt,
+	}
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	TrashTypeProduct  = "product"
+	TrashTypeCategory = "category"
+)
+
+var (
+	ErrNotInTrash        = errors.New("item is not in the trash")
+	ErrReferencedByOrder = errors.New("item is referenced by existing orders")
+	ErrBundleComponent   = errors.New("product is a component of a bundle that is not in the trash")
+	ErrCategoryNotEmpty  = errors.New("category still has products that are not in the trash")
+)
+
+var _ TrashServiceInterface = (*TrashService)(nil)
+
+// PurgeSummary reports the outcome of a PurgeExpired run
+type PurgeSummary struct {
+	Products   int
+	Categories int
+
+	// Skipped counts expired items kept because orders or live bundles still
+	// reference them, or live products still use the category
+	Skipped int
+}
+
+type TrashService struct {
+	db     *gorm.DB
+	config *config.Config
+	media  interfaces.UploadProvider
+}
+
+func NewTrashService(db *gorm.DB, config *config.Config, media interfaces.UploadProvider) *TrashService {
+	return &TrashService{
+		db:     db,
+		config: config,
+		media:  media,
+	}
+}
+
+func (s *TrashService) GetTrash(req *dto.ListTrashRequest) ([]dto.TrashItemResponse, *utils.PaginationMeta, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	if req.Limit < 1 {
+		req.Limit = 10
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	var total int64
+	var items []dto.TrashItemResponse
+
+	switch req.Type {
+	case TrashTypeCategory:
+		query := s.db.Unscoped().Model(&models.Category{}).Where("deleted_at IS NOT NULL")
+		query.Count(&total)
+
+		var categories []models.Category
+		if err := query.Order("deleted_at DESC").Offset(offset).Limit(req.Limit).Find(&categories).Error; err != nil {
+			return nil, nil, err
+		}
+
+		items = make([]dto.TrashItemResponse, len(categories))
+		for i := range categories {
+			items[i] = dto.TrashItemResponse{
+				ID:        categories[i].ID,
+				Type:      TrashTypeCategory,
+				Name:      categories[i].Name,
+				DeletedAt: categories[i].DeletedAt.Time,
+				PurgeAt:   categories[i].DeletedAt.Time.Add(s.config.Trash.RetentionPeriod),
+			}
+		}
+	default:
+		query := s.db.Unscoped().Model(&models.Product{}).Where("deleted_at IS NOT NULL")
+		query.Count(&total)
+
+		var products []models.Product
+		if err := query.Order("deleted_at DESC").Offset(offset).Limit(req.Limit).Find(&products).Error; err != nil {
+			return nil, nil, err
+		}
+
+		items = make([]dto.TrashItemResponse, len(products))
+		for i := range products {
+			items[i] = dto.TrashItemResponse{
+				ID:         products[i].ID,
+				Type:       TrashTypeProduct,
+				Name:       products[i].Name,
+				SKU:        products[i].SKU,
+				CategoryID: products[i].CategoryID,
+				DeletedAt:  products[i].DeletedAt.Time,
+				PurgeAt:    products[i].DeletedAt.Time.Add(s.config.Trash.RetentionPeriod),
+			}
+		}
+	}
+
+	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+	meta := &utils.PaginationMeta{
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return items, meta, nil
+}
+
+func (s *TrashService) RestoreProduct(actorID, id uint) (*dto.ProductResponse, error) {
+	product, err := s.trashedProduct(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var category models.Category
+	if err := s.db.Unscoped().First(&category, product.CategoryID).Error; err != nil {
+		return nil, err
+	}
+
+	if category.DeletedAt.Valid {
+		return nil, errors.New("the product's category is in the trash, restore it first")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Product{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		return recordRevision(tx, actorID, RevisionEntityProduct, id, models.RevisionActionRestore, nil, nil)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var restored models.Product
+	if err := preloadProduct(s.db, "", "").First(&restored, id).Error; err != nil {
+		return nil, err
+	}
+
+	prices, err := loadPriceList(s.db, s.config.Store.BaseCurrency, "")
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertToProductResponse(&restored, prices, s.media)
+	return &response, nil
+}
+
+// RestoreCategory takes a category out of the trash. With cascade it also
+// restores the products that were trashed together with it; products deleted
+// on their own before the category stay in the trash.
+func (s *TrashService) RestoreCategory(actorID, id uint, cascade bool) (*dto.CategoryResponse, error) {
+	var category models.Category
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&category, id).Error; err != nil {
+		return nil, ErrNotInTrash
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Category{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := recordRevision(tx, actorID, RevisionEntityCategory, id, models.RevisionActionRestore, nil, nil); err != nil {
+			return err
+		}
+
+		if !cascade {
+			return nil
+		}
+
+		var productIDs []uint
+		if err := tx.Unscoped().Model(&models.Product{}).
+			Where("category_id = ? AND deleted_at = ?", id, category.DeletedAt.Time).
+			Pluck("id", &productIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.Product{}).Where("id IN ?", productIDs).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		for _, productID := range productIDs {
+			if err := recordRevision(tx, actorID, RevisionEntityProduct, productID, models.RevisionActionRestore, nil, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &dto.CategoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		IsActive:    category.IsActive,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}, nil
+}
+
+func (s *TrashService) PurgeProduct(id uint) error {
+	var paths []string
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		product, err := s.trashedProduct(tx, id)
+		if err != nil {
+			return err
+		}
+
+		paths, err = purgeProduct(tx, product)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	deleteFiles(s.media, paths)
+	return nil
+}
+
+// PurgeCategory permanently deletes a trashed category together with its
+// trashed products. It fails while the category still has live products or
+// any of its products is referenced by an order or a live bundle.
+func (s *TrashService) PurgeCategory(id uint) error {
+	var paths []string
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&category, id).Error; err != nil {
+			return ErrNotInTrash
+		}
+
+		var live int64
+		if err := tx.Model(&models.Product{}).Where("category_id = ?", id).Count(&live).Error; err != nil {
+			return err
+		}
+
+		if live > 0 {
+			return ErrCategoryNotEmpty
+		}
+
+		var products []models.Product
+		if err := tx.Unscoped().Where("category_id = ?", id).Find(&products).Error; err != nil {
+			return err
+		}
+
+		for i := range products {
+			productPaths, err := purgeProduct(tx, &products[i])
+			if err != nil {
+				return fmt.Errorf("product %s: %w", products[i].SKU, err)
+			}
+			paths = append(paths, productPaths...)
+		}
+
+		return tx.Unscoped().Delete(&category).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	deleteFiles(s.media, paths)
+	return nil
+}
+
+// PurgeExpired permanently deletes everything trashed before cutoff. Items
+// still referenced are skipped and reported in the summary, while any other
+// failure stops the purge.
+func (s *TrashService) PurgeExpired(cutoff time.Time) (*PurgeSummary, error) {
+	summary := &PurgeSummary{}
+
+	var productIDs []uint
+	if err := s.db.Unscoped().Model(&models.Product{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &productIDs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range productIDs {
+		err := s.PurgeProduct(id)
+		switch {
+		case err == nil:
+			summary.Products++
+		case isStillReferenced(err):
+			summary.Skipped++
+		default:
+			return summary, fmt.Errorf("unable to purge product %d: %w", id, err)
+		}
+	}
+
+	var categoryIDs []uint
+	if err := s.db.Unscoped().Model(&models.Category{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &categoryIDs).Error; err != nil {
+		return summary, err
+	}
+
+	for _, id := range categoryIDs {
+		// Categories whose products could not be purged stay in the trash
+		err := s.PurgeCategory(id)
+		switch {
+		case err == nil:
+			summary.Categories++
+		case isStillReferenced(err):
+			summary.Skipped++
+		default:
+			return summary, fmt.Errorf("unable to purge category %d: %w", id, err)
+		}
+	}
+
+	return summary, nil
+}
+
+// isStillReferenced reports whether a purge failed because the item is still
+// in use, so it stays in the trash until it no longer is
+func isStillReferenced(err error) bool {
+	return errors.Is(err, ErrReferencedByOrder) ||
+		errors.Is(err, ErrBundleComponent) ||
+		errors.Is(err, ErrCategoryNotEmpty)
+}
+
+func (s *TrashService) trashedProduct(tx *gorm.DB, id uint) (*models.Product, error) {
+	var product models.Product
+	if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&product, id).Error; err != nil {
+		return nil, ErrNotInTrash
+	}
+
+	return &product, nil
+}
+
+// purgeProduct hard deletes a product. Order items and bundle items cascade on
+// product delete, so products that were ever ordered must be kept to preserve
+// order history, and components of live bundles to keep the bundles whole.
+func purgeProduct(tx *gorm.DB, product *models.Product) ([]string, error) {
+	var ordered int64
+	if err := tx.Model(&models.OrderItem{}).Where("product_id = ?", product.ID).Count(&ordered).Error; err != nil {
+		return nil, err
+	}
+
+	if ordered == 0 {
+		if err := tx.Model(&models.OrderItemComponent{}).Where("product_id = ?", product.ID).Count(&ordered).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if ordered > 0 {
+		return nil, ErrReferencedByOrder
+	}
+
+	var bundles int64
+	if err := tx.Model(&models.BundleItem{}).
+		Joins("JOIN products ON products.id = bundle_items.bundle_id AND products.deleted_at IS NULL").
+		Where("bundle_items.component_id = ?", product.ID).
+		Count(&bundles).Error; err != nil {
+		return nil, err
+	}
+
+	if bundles > 0 {
+		return nil, ErrBundleComponent
+	}
+
+	// The images themselves are deleted with the product by the foreign key
+	var images []models.ProductImage
+	if err := tx.Unscoped().Preload("Renditions").Where("product_id = ?", product.ID).Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for i := range images {
+		imagePaths, err := releaseImageFiles(tx, &images[i])
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, imagePaths...)
+	}
+
+	if err := tx.Unscoped().Delete(product).Error; err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/providers"
+)
+
+const (
+	fakeS3Bucket = "images"
+	fakeS3Region = "us-east-1"
+)
+
+var fakeS3Credentials = aws.Credentials{AccessKeyID: "test", SecretAccessKey: "secret"}
+
+type fakeS3Object struct {
+	data        []byte
+	contentType string
+}
+
+// fakeS3 is an S3-compatible stand-in for a single path-style bucket. Like S3
+// it checks the signature of presigned requests, so an upload that differs
+// from the one presigned is refused.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	fake := &fakeS3{objects: map[string]fakeS3Object{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+fakeS3Bucket+"/")
+	if !ok {
+		http.Error(w, "no such bucket", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Has("X-Amz-Signature") {
+		if !f.validPresignature(r) {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+			return
+		}
+	} else if r.Header.Get("Authorization") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>AccessDenied</Code></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = fakeS3Object{data: data, contentType: r.Header.Get("Content-Type")}
+	case http.MethodHead, http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			checksum := binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(object.data))
+			w.Header().Set("X-Amz-Checksum-Crc32", base64.StdEncoding.EncodeToString(checksum))
+			w.Write(object.data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// validPresignature signs the request again, with the headers it was sent
+// with, and compares the result with the signature it carries
+func (f *fakeS3) validPresignature(r *http.Request) bool {
+	query := r.URL.Query()
+
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || time.Since(signedAt) > time.Duration(expires)*time.Second {
+		return false
+	}
+
+	signature := query.Get("X-Amz-Signature")
+	query.Del("X-Amz-Signature")
+
+	unsigned, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.EscapedPath()+"?"+query.Encode(), nil)
+	if err != nil {
+		return false
+	}
+	unsigned.ContentLength = r.ContentLength
+	for _, name := range strings.Split(query.Get("X-Amz-SignedHeaders"), ";") {
+		if name != "host" && name != "content-length" {
+			unsigned.Header[http.CanonicalHeaderKey(name)] = r.Header.Values(name)
+		}
+	}
+
+	signedURL, _, err := v4.NewSigner().PresignHTTP(context.Background(), fakeS3Credentials, unsigned,
+		"UNSIGNED-PAYLOAD", "s3", fakeS3Region, signedAt, func(o *v4.SignerOptions) {
+			o.DisableURIPathEscaping = true
+		})
+	if err != nil {
+		return false
+	}
+
+	resigned, err := url.Parse(signedURL)
+	return err == nil && resigned.Query().Get("X-Amz-Signature") == signature
+}
+
+func (f *fakeS3) object(key string) (fakeS3Object, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	object, ok := f.objects[key]
+	return object, ok
+}
+
+func (f *fakeS3) putObject(key, contentType string, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.objects[key] = fakeS3Object{data: data, contentType: contentType}
+}
+
+// newTestDirectUploadService returns an upload service storing images in the
+// fake bucket
+func newTestDirectUploadService(t *testing.T) (*UploadService, sqlmock.Sqlmock, *fakeS3) {
+	t.Helper()
+
+	fake, server := newFakeS3(t)
+
+	cfg := &config.Config{}
+	cfg.AWS.Region = fakeS3Region
+	cfg.AWS.AccessKeyID = fakeS3Credentials.AccessKeyID
+	cfg.AWS.SecretAccessKey = fakeS3Credentials.SecretAccessKey
+	cfg.AWS.S3Endpoint = server.URL
+	cfg.Upload.MaxFileSize = 1 << 20
+	cfg.Upload.MaxImageWidth = 1024
+	cfg.Upload.MaxImageHeight = 1024
+	cfg.Upload.MaxImagePixels = 1 << 20
+	cfg.Upload.PresignTTL = time.Minute
+
+	provider := providers.NewS3Provider(cfg, fakeS3Bucket, providers.S3URLOptions{})
+	db, mock := newMockDB(t)
+
+	return NewUploadService(db, cfg, provider, provider), mock, fake
+}
+
+// presign presigns an upload of image for product 1, as pending upload 1
+func presign(t *testing.T, s *UploadService, mock sqlmock.Sqlmock, image []byte) (*dto.PresignedUploadResponse, string) {
+	t.Helper()
+
+	var key string
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "pending_uploads"`).
+		WithArgs(uint(1), keyArg{&key}, "photo.png", "image/png", int64(len(image)), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	presigned, err := s.PresignProductImage(1, &dto.PresignImageUploadRequest{
+		Filename:    "photo.png",
+		ContentType: "image/png",
+		Size:        int64(len(image)),
+	})
+	if err != nil {
+		t.Fatalf("PresignProductImage() error = %v", err)
+	}
+
+	return presigned, key
+}
+
+// keyArg matches any object key, and keeps it
+type keyArg struct{ key *string }
+
+func (a keyArg) Match(v driver.Value) bool {
+	key, ok := v.(string)
+	*a.key = key
+	return ok && strings.HasPrefix(key, "uploads/products/1/")
+}
+
+// upload sends body as the presigned request does, with contentType instead of
+// the signed content type if it is set
+func upload(t *testing.T, presigned *dto.PresignedUploadResponse, body []byte, contentType string) int {
+	t.Helper()
+
+	req, err := http.NewRequest(presigned.Method, presigned.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+	for name, value := range presigned.Headers {
+		if !strings.EqualFold(name, "Content-Length") {
+			req.Header.Set(name, value)
+		}
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("upload error = %v", err)
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func expectPendingUpload(mock sqlmock.Sqlmock, key string, size int64) {
+	mock.ExpectQuery(`SELECT \* FROM "pending_uploads"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "key", "filename", "content_type", "size", "expires_at", "created_at"}).
+			AddRow(1, 1, key, "photo.png", "image/png", size, time.Now().Add(time.Minute), time.Now()))
+}
+
+func expectPendingUploadDeleted(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "pending_uploads"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestDirectUpload(t *testing.T) {
+	image := testPNG(t)
+
+	t.Run("presign, upload and confirm", func(t *testing.T) {
+		s, mock, fake := newTestDirectUploadService(t)
+
+		presigned, key := presign(t, s, mock, image)
+		if status := upload(t, presigned, image, ""); status != http.StatusOK {
+			t.Fatalf("upload status = %d, want %d", status, http.StatusOK)
+		}
+
+		expectPendingUpload(mock, key, int64(len(image)))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "image_objects" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"content_hash", "ref_count"}))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "image_objects" .* ON CONFLICT`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectPendingUploadDeleted(mock)
+
+		uploaded, err := s.ConfirmProductImage(1, 1)
+		if err != nil {
+			t.Fatalf("ConfirmProductImage() error = %v", err)
+		}
+
+		original, ok := fake.object(uploaded.URL)
+		if !ok || !bytes.Equal(original.data, image) {
+			t.Errorf("the original image was not stored at %s", uploaded.URL)
+		}
+		if len(uploaded.Renditions) == 0 {
+			t.Error("no renditions were stored")
+		}
+		for _, rendition := range uploaded.Renditions {
+			if _, ok := fake.object(rendition.Path); !ok {
+				t.Errorf("rendition %s was not stored at %s", rendition.Name, rendition.Path)
+			}
+		}
+		if _, ok := fake.object(key); ok {
+			t.Error("the direct upload was not removed")
+		}
+	})
+
+	t.Run("upload not matching the presigned request", func(t *testing.T) {
+		s, mock, _ := newTestDirectUploadService(t)
+
+		presigned, key := presign(t, s, mock, image)
+		if status := upload(t, presigned, image, "image/gif"); status != http.StatusForbidden {
+			t.Errorf("upload with another content type status = %d, want %d", status, http.StatusForbidden)
+		}
+		if status := upload(t, presigned, append(image, 0), ""); status != http.StatusForbidden {
+			t.Errorf("upload of another size status = %d, want %d", status, http.StatusForbidden)
+		}
+
+		expectPendingUpload(mock, key, int64(len(image)))
+
+		if _, err := s.ConfirmProductImage(1, 1); !errors.Is(err, ErrUploadNotReceived) {
+			t.Errorf("ConfirmProductImage() error = %v, want %v", err, ErrUploadNotReceived)
+		}
+	})
+
+	t.Run("stored object not matching the pending upload", func(t *testing.T) {
+		s, mock, fake := newTestDirectUploadService(t)
+
+		_, key := presign(t, s, mock, image)
+		fake.putObject(key, "image/png", append(image, 0))
+
+		expectPendingUpload(mock, key, int64(len(image)))
+		expectPendingUploadDeleted(mock)
+
+		_, err := s.ConfirmProductImage(1, 1)
+		var validationErr *UploadValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != UploadMismatch {
+			t.Fatalf("ConfirmProductImage() error = %v, want a %s error", err, UploadMismatch)
+		}
+		if _, ok := fake.object(key); ok {
+			t.Error("the mismatched upload was not removed")
+		}
+	})
+}
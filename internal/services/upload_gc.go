@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/interfaces"
+	"github.com/joefazee/learning-go-shop/internal/models"
+)
+
+// uploadGCPrefixes are where product images and direct uploads are stored
+var uploadGCPrefixes = []string{"products/", "uploads/"}
+
+// UploadGCReport reports the outcome of a CollectOrphanedUploads run. In a dry
+// run nothing is deleted and the totals are what would have been reclaimed.
+type UploadGCReport struct {
+	DryRun  bool
+	Scanned int
+	// Orphans are the unreferenced files old enough to be deleted
+	Orphans        []interfaces.StoredObject
+	Deleted        int
+	Failed         int
+	BytesReclaimed int64
+}
+
+// CollectOrphanedUploads deletes stored public files that no product image,
+// rendition or pending direct upload refers to. Files modified after cutoff
+// are kept, as they may belong to an upload that is still being saved.
+func (s *UploadService) CollectOrphanedUploads(cutoff time.Time, dryRun bool) (*UploadGCReport, error) {
+	referenced, err := s.referencedUploads(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UploadGCReport{DryRun: dryRun}
+
+	for _, prefix := range uploadGCPrefixes {
+		objects, err := s.provider.List(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %s: %w", prefix, err)
+		}
+
+		for _, object := range objects {
+			report.Scanned++
+
+			if referenced[object.Key] || object.ModifiedAt.After(cutoff) {
+				continue
+			}
+
+			report.Orphans = append(report.Orphans, object)
+			if dryRun {
+				report.BytesReclaimed += object.Size
+				continue
+			}
+
+			if err := s.provider.DeleteFile(object.Key); err != nil {
+				log.Printf("unable to delete orphaned upload %s: %v", object.Key, err)
+				report.Failed++
+				continue
+			}
+
+			report.Deleted++
+			report.BytesReclaimed += object.Size
+		}
+	}
+
+	if !dryRun {
+		// Their files were deleted above, or never arrived
+		if err := s.db.Where("expires_at < ?", cutoff).Delete(&models.PendingUpload{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// referencedUploads returns the keys of every file still in use. Images of
+// trashed products are kept, as the products can be restored, and so are
+// direct uploads that expired after cutoff, as they may yet be confirmed.
+func (s *UploadService) referencedUploads(cutoff time.Time) (map[string]bool, error) {
+	var keys, renditions, pending []string
+
+	if err := s.db.Unscoped().Model(&models.ProductImage{}).Pluck("url", &keys).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.ProductImageRendition{}).Pluck("path", &renditions).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.PendingUpload{}).Where("expires_at >= ?", cutoff).Pluck("key", &pending).Error; err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(keys)+len(renditions)+len(pending))
+	for _, group := range [][]string{keys, renditions, pending} {
+		for _, key := range group {
+			referenced[key] = true
+		}
+	}
+
+	return referenced, nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// maxImportRedirects is the most redirects followed when importing an image
+const maxImportRedirects = 3
+
+var errNonPublicAddress = errors.New("address is not public")
+
+// reservedPrefixes are the IPv4 ranges that aren't publicly routable but
+// aren't covered by the net.IP checks
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// newImportClient returns the client images are imported from URLs with. It
+// only connects to the addresses allowed, checked once host names are
+// resolved so a name can't point it at internal services, and follows a few
+// redirects to http and https URLs, each checked the same way.
+func newImportClient(timeout time.Duration, allowed func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return fmt.Errorf("%w: %s", errNonPublicAddress, host)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would be dialed instead of the image's host, so its address
+	// is all the check would see
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxImportRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxImportRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirected to an unsupported %q URL", req.URL.Scheme)
+			}
+
+			return nil
+		},
+	}
+}
+
+// isPublicIP reports whether ip is a publicly routable unicast address, so not
+// a loopback, private, link-local (such as cloud metadata services) or
+// otherwise reserved one
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestImportService returns an upload service that imports with the
+// production checks, except that the loopback test servers are reachable
+func newTestImportService(maxFileSize int64) *UploadService {
+	return &UploadService{
+		maxFileSize: maxFileSize,
+		httpClient: newImportClient(time.Second, func(ip net.IP) bool {
+			return ip.IsLoopback() || isPublicIP(ip)
+		}),
+	}
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestFetch(t *testing.T) {
+	image := testPNG(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(image)
+	})
+	mux.HandleFunc("/large.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(make([]byte, 2048))
+	})
+	mux.HandleFunc("/streamed.png", func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before the end leaves the length undeclared
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(make([]byte, 512))
+		w.(http.Flusher).Flush()
+		w.Write(make([]byte, 1536))
+	})
+	mux.HandleFunc("/page.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(image)
+	})
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://10.0.0.1/image.png", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		code    string
+		message string
+	}{
+		{name: "image", path: "/image.png"},
+		{name: "declared size over the limit", path: "/large.png", code: UploadFileTooLarge},
+		{name: "streamed size over the limit", path: "/streamed.png", code: UploadFileTooLarge},
+		{name: "wrong content type", path: "/page.html", code: UploadUnsupportedType},
+		{name: "redirect to link-local address", path: "/metadata", code: UploadFetchFailed, message: "not public"},
+		{name: "redirect to private address", path: "/private", code: UploadFetchFailed, message: "not public"},
+		{name: "too many redirects", path: "/loop", code: UploadFetchFailed, message: "redirects"},
+		{name: "redirect to another scheme", path: "/file", code: UploadFetchFailed, message: "unsupported"},
+	}
+
+	s := newTestImportService(1024)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := s.fetch(server.URL + tt.path)
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("fetch() error = %v", err)
+				}
+				if !bytes.Equal(data, image) {
+					t.Errorf("fetch() returned %d bytes, want the %d byte image", len(data), len(image))
+				}
+				return
+			}
+
+			var validationErr *UploadValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("fetch() error = %v, want an upload validation error", err)
+			}
+			if validationErr.Code != tt.code {
+				t.Errorf("fetch() error code = %s, want %s (%v)", validationErr.Code, tt.code, err)
+			}
+			if !strings.Contains(validationErr.Message, tt.message) {
+				t.Errorf("fetch() error = %q, want it to mention %q", validationErr.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestFetchRejectsLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the server was reached")
+	}))
+	defer server.Close()
+
+	s := &UploadService{maxFileSize: 1024, httpClient: newImportClient(time.Second, isPublicIP)}
+
+	_, err := s.fetch(server.URL)
+	var validationErr *UploadValidationError
+	if !errors.As(err, &validationErr) || validationErr.Code != UploadFetchFailed {
+		t.Fatalf("fetch() error = %v, want a %s error", err, UploadFetchFailed)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
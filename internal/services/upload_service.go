@@ -1,45 +1,593 @@
 package services
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"mime"
 	"mime/multipart"
+	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/imaging"
 	"github.com/joefazee/learning-go-shop/internal/interfaces"
+	"github.com/joefazee/learning-go-shop/internal/models"
 )
 
+var (
+	ErrDirectUploadUnsupported = errors.New("direct uploads are not supported by the storage provider")
+	ErrPendingUploadNotFound   = errors.New("pending upload not found")
+	ErrUploadNotReceived       = errors.New("upload has not been received")
+)
+
+// UploadedImage is a stored product image: the original, stripped of its
+// metadata, and its renditions
+type UploadedImage struct {
+	URL         string
+	ContentHash string
+	Renditions  []models.ProductImageRendition
+}
+
+// Upload validation error codes
+const (
+	UploadFileTooLarge       = "file_too_large"
+	UploadEmptyFile          = "empty_file"
+	UploadUnsupportedType    = "unsupported_type"
+	UploadExtensionMismatch  = "extension_mismatch"
+	UploadDimensionsTooLarge = "dimensions_too_large"
+	UploadCorruptImage       = "corrupt_image"
+	UploadMismatch           = "upload_mismatch"
+	UploadInvalidURL         = "invalid_url"
+	UploadFetchFailed        = "fetch_failed"
+)
+
+// UploadValidationError explains why an upload was rejected. Its fields are
+// returned to the client as is.
+type UploadValidationError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *UploadValidationError) Error() string {
+	return e.Message
+}
+
+// imageExtFormats maps the extensions accepted for images to the format their
+// content must be in
+var imageExtFormats = map[string]string{
+	".jpg":  imaging.FormatJPEG,
+	".jpeg": imaging.FormatJPEG,
+	".png":  imaging.FormatPNG,
+	".gif":  imaging.FormatGIF,
+	".webp": imaging.FormatWebP,
+}
+
 var _ UploadServiceInterface = (*UploadService)(nil)
 
 type UploadService struct {
+	db       *gorm.DB
 	provider interfaces.UploadProvider
+	// privateProvider stores files that must never be publicly served
+	privateProvider interfaces.UploadProvider
+	maxFileSize     int64
+	imageLimits     imaging.Limits
+	presignTTL      time.Duration
+	// httpClient fetches images imported from a URL, from public addresses
+	// only
+	httpClient *http.Client
+}
+
+func NewUploadService(db *gorm.DB, cfg *config.Config, provider, privateProvider interfaces.UploadProvider) *UploadService {
+	return &UploadService{
+		db:              db,
+		provider:        provider,
+		privateProvider: privateProvider,
+		maxFileSize:     cfg.Upload.MaxFileSize,
+		imageLimits: imaging.Limits{
+			MaxWidth:  cfg.Upload.MaxImageWidth,
+			MaxHeight: cfg.Upload.MaxImageHeight,
+			MaxPixels: cfg.Upload.MaxImagePixels,
+		},
+		presignTTL: cfg.Upload.PresignTTL,
+		httpClient: newImportClient(cfg.Upload.ImportTimeout, isPublicIP),
+	}
+}
+
+// UploadProductImage validates an image by its content, processes it into its
+// renditions and stores them. Nothing is kept if any of them fails to store.
+func (s *UploadService) UploadProductImage(productID uint, file *multipart.FileHeader) (*UploadedImage, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return s.UploadProductImageFrom(productID, file.Filename, file.Size, src)
+}
+
+// UploadProductImageFrom is UploadProductImage for an image that isn't a form
+// file, such as a GraphQL upload. The size is the one the client declared.
+func (s *UploadService) UploadProductImageFrom(productID uint, filename string, size int64, src io.Reader) (*UploadedImage, error) {
+	if err := s.checkProductExists(productID); err != nil {
+		return nil, err
+	}
+
+	data, err := s.readImage("image", filename, size, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storeImage("image", data)
+}
+
+// ImportProductImage fetches an image from a URL and stores it like an uploaded
+// one. The fetch is bounded by the import timeout and the maximum file size,
+// and only reaches public addresses.
+func (s *UploadService) ImportProductImage(productID uint, rawURL string) (*UploadedImage, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, &UploadValidationError{Field: "url", Code: UploadInvalidURL, Message: "url must be an absolute http or https URL"}
+	}
+
+	if err := s.checkProductExists(productID); err != nil {
+		return nil, err
+	}
+
+	data, err := s.fetch(parsed.String())
+	if err != nil {
+		return nil, err
+	}
+
+	format := imaging.Sniff(data)
+	if format == "" {
+		return nil, &UploadValidationError{Field: "url", Code: UploadUnsupportedType, Message: "url does not point to a supported image"}
+	}
+
+	if err := s.validateImage("url", "."+format, data); err != nil {
+		return nil, err
+	}
+
+	return s.storeImage("url", data)
+}
+
+// fetch downloads the body of a URL, within the size limit
+func (s *UploadService) fetch(rawURL string) ([]byte, error) {
+	resp, err := s.httpClient.Get(rawURL)
+	if err != nil {
+		return nil, &UploadValidationError{Field: "url", Code: UploadFetchFailed, Message: fmt.Sprintf("unable to fetch image: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &UploadValidationError{
+			Field:   "url",
+			Code:    UploadFetchFailed,
+			Message: fmt.Sprintf("unable to fetch image: server responded with %s", resp.Status),
+		}
+	}
+
+	// The content is sniffed either way, but a server saying it isn't sending
+	// an image is believed
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if !strings.HasPrefix(mediaType, "image/") && mediaType != "application/octet-stream" {
+			return nil, &UploadValidationError{
+				Field:   "url",
+				Code:    UploadUnsupportedType,
+				Message: fmt.Sprintf("url returned %s content, not an image", mediaType),
+			}
+		}
+	}
+
+	// A declared length over the limit is rejected before reading the body
+	if resp.ContentLength > 0 {
+		if err := s.checkSize("url", resp.ContentLength); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := s.readLimited("url", resp.Body)
+	var validationErr *UploadValidationError
+	if err != nil && !errors.As(err, &validationErr) {
+		return nil, &UploadValidationError{Field: "url", Code: UploadFetchFailed, Message: fmt.Sprintf("unable to fetch image: %v", err)}
+	}
+
+	return data, err
+}
+
+// PresignProductImage lets a client upload an image straight to storage,
+// instead of through the API. The key, content type and size are fixed by the
+// signature, and the upload is only added to the product once confirmed.
+func (s *UploadService) PresignProductImage(productID uint, req *dto.PresignImageUploadRequest) (*dto.PresignedUploadResponse, error) {
+	direct, ok := s.provider.(interfaces.DirectUploadProvider)
+	if !ok {
+		return nil, ErrDirectUploadUnsupported
+	}
+
+	ext := strings.ToLower(filepath.Ext(req.Filename))
+	format, ok := imageExtFormats[ext]
+	if !ok {
+		return nil, &UploadValidationError{
+			Field:   "filename",
+			Code:    UploadUnsupportedType,
+			Message: fmt.Sprintf("invalid file type: %s", ext),
+		}
+	}
+	if req.ContentType != "image/"+format {
+		return nil, &UploadValidationError{
+			Field:   "content_type",
+			Code:    UploadExtensionMismatch,
+			Message: fmt.Sprintf("content type %s does not match a %s file", req.ContentType, ext),
+		}
+	}
+	if err := s.checkSize("size", req.Size); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkProductExists(productID); err != nil {
+		return nil, err
+	}
+
+	pending := models.PendingUpload{
+		ProductID:   productID,
+		Key:         fmt.Sprintf("uploads/products/%d/%s%s", productID, uuid.New().String(), ext),
+		Filename:    req.Filename,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		ExpiresAt:   time.Now().Add(s.presignTTL),
+	}
+
+	presigned, err := direct.PresignUpload(pending.Key, pending.ContentType, pending.Size, s.presignTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&pending).Error; err != nil {
+		return nil, err
+	}
+
+	return &dto.PresignedUploadResponse{
+		UploadID:  pending.ID,
+		URL:       presigned.URL,
+		Method:    presigned.Method,
+		Headers:   presigned.Headers,
+		ExpiresAt: pending.ExpiresAt,
+	}, nil
+}
+
+// ConfirmProductImage processes an image the client has uploaded directly. The
+// upload is validated like any other and the object the client uploaded is
+// removed once its renditions are stored. An upload that fails validation is
+// removed too, while one that has not arrived yet can be confirmed again.
+func (s *UploadService) ConfirmProductImage(productID, uploadID uint) (*UploadedImage, error) {
+	if _, ok := s.provider.(interfaces.DirectUploadProvider); !ok {
+		return nil, ErrDirectUploadUnsupported
+	}
+
+	var pending models.PendingUpload
+	if err := s.db.Where("id = ? AND product_id = ?", uploadID, productID).First(&pending).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPendingUploadNotFound
+		}
+		return nil, err
+	}
+
+	info, err := s.provider.Stat(pending.Key)
+	if errors.Is(err, interfaces.ErrObjectNotFound) {
+		return nil, ErrUploadNotReceived
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := s.confirmImage(&pending, info)
+	var validationErr *UploadValidationError
+	if err != nil && !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	s.DeleteFiles([]string{pending.Key})
+	if err := s.db.Delete(&pending).Error; err != nil {
+		log.Printf("unable to delete pending upload %d: %v", pending.ID, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uploaded, nil
 }
 
-func NewUploadService(provider interfaces.UploadProvider) *UploadService {
-	return &UploadService{provider: provider}
+func (s *UploadService) confirmImage(pending *models.PendingUpload, info *interfaces.ObjectInfo) (*UploadedImage, error) {
+	if info.Size != pending.Size || info.ContentType != pending.ContentType {
+		return nil, &UploadValidationError{
+			Field:   "upload_id",
+			Code:    UploadMismatch,
+			Message: fmt.Sprintf("uploaded %d bytes of %s, expected %d bytes of %s", info.Size, info.ContentType, pending.Size, pending.ContentType),
+		}
+	}
+
+	src, err := s.provider.Open(pending.Key)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	data, err := s.readLimited("upload_id", src)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateImage("upload_id", filepath.Ext(pending.Key), data); err != nil {
+		return nil, err
+	}
+
+	return s.storeImage("upload_id", data)
+}
+
+// storeImage processes a validated image and stores the original and its
+// renditions under keys named by the SHA-256 of data, so they never change and
+// are served as immutable. If an image with the same content is stored
+// already its files are shared instead. Either way the use of the files is
+// counted, so the image must be added or released. Nothing is kept if any of
+// them fails to store.
+func (s *UploadService) storeImage(field string, data []byte) (*UploadedImage, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	existing, err := s.retainStoredImage(hash)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	renditions, err := imaging.Process(bytes.NewReader(data))
+	if errors.Is(err, imaging.ErrUnsupportedImage) {
+		return nil, &UploadValidationError{Field: field, Code: UploadCorruptImage, Message: err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	base := fmt.Sprintf("%s%s/%s", interfaces.ContentAddressedPrefix, hash[:2], hash)
+
+	uploaded := UploadedImage{ContentHash: hash}
+	var stored []string
+	for i := range renditions {
+		rendition := &renditions[i]
+
+		path := base + rendition.Ext()
+		if rendition.Name != imaging.Original {
+			path = fmt.Sprintf("%s_%s%s", base, rendition.Name, rendition.Ext())
+		}
+
+		key, err := s.provider.Put(path, bytes.NewReader(rendition.Data), interfaces.PutOptions{
+			ContentType:  rendition.ContentType(),
+			Size:         int64(len(rendition.Data)),
+			CacheControl: interfaces.ImmutableCacheControl,
+		})
+		if err != nil {
+			s.DeleteFiles(stored)
+			return nil, fmt.Errorf("unable to store %s image: %w", rendition.Name, err)
+		}
+		stored = append(stored, key)
+
+		if rendition.Name == imaging.Original {
+			uploaded.URL = key
+			continue
+		}
+
+		uploaded.Renditions = append(uploaded.Renditions, models.ProductImageRendition{
+			Name:   rendition.Name,
+			Format: rendition.Format,
+			Path:   key,
+			Width:  rendition.Width,
+			Height: rendition.Height,
+			Size:   int64(len(rendition.Data)),
+		})
+	}
+
+	// The files are left for upload garbage collection if this fails, as a
+	// concurrent upload of the same content may be using them
+	if err := retainImageObject(s.db, hash); err != nil {
+		return nil, err
+	}
+
+	return &uploaded, nil
 }
 
-func (s *UploadService) UploadProductImage(productID uint, file *multipart.FileHeader) (string, error) {
+func (s *UploadService) UploadProductFile(productID uint, file *multipart.FileHeader) (string, error) {
+	if err := s.checkSize("file", file.Size); err != nil {
+		return "", err
+	}
 
 	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if !isValidImageExt(ext) {
-		return "", fmt.Errorf("invalid file type: %s", ext)
+	path := fmt.Sprintf("digital/%d/%s%s", productID, uuid.New().String(), ext)
+
+	return interfaces.PutMultipart(s.privateProvider, file, path)
+}
+
+// retainStoredImage counts another use of the files stored for an image with
+// the given content hash and returns them, or returns nil if there are none
+// to share. The count is locked while the files are looked up, so they can't
+// be released in between.
+func (s *UploadService) retainStoredImage(hash string) (*UploadedImage, error) {
+	var uploaded *UploadedImage
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var object models.ImageObject
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("content_hash = ?", hash).
+			First(&object).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The files were released, or never stored, so they are stored
+			// again
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var image models.ProductImage
+		err = tx.Unscoped().Preload("Renditions").Where("content_hash = ?", hash).First(&image).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&object).Update("ref_count", gorm.Expr("ref_count + 1")).Error; err != nil {
+			return err
+		}
+
+		uploaded = &UploadedImage{URL: image.URL, ContentHash: hash}
+		for _, rendition := range image.Renditions {
+			rendition.ID = 0
+			rendition.ProductImageID = 0
+			uploaded.Renditions = append(uploaded.Renditions, rendition)
+		}
+
+		return nil
+	})
+
+	return uploaded, err
+}
+
+// DeleteFiles removes stored public files, such as those of a deleted image or
+// of an upload that failed part way. Failures are logged, not returned.
+func (s *UploadService) DeleteFiles(paths []string) {
+	deleteFiles(s.provider, paths)
+}
+
+func deleteFiles(provider interfaces.UploadProvider, paths []string) {
+	for _, path := range paths {
+		if err := provider.DeleteFile(path); err != nil {
+			log.Printf("unable to delete %s: %v", path, err)
+		}
+	}
+}
+
+func (s *UploadService) checkProductExists(productID uint) error {
+	var count int64
+	if err := s.db.Model(&models.Product{}).Where("id = ?", productID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrProductNotFound
+	}
+
+	return nil
+}
+
+// readImage reads an uploaded image and checks it is within the size limits
+// and that its content is an image of the type its extension claims
+func (s *UploadService) readImage(field, filename string, size int64, src io.Reader) ([]byte, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if _, ok := imageExtFormats[ext]; !ok {
+		return nil, &UploadValidationError{
+			Field:   field,
+			Code:    UploadUnsupportedType,
+			Message: fmt.Sprintf("invalid file type: %s", ext),
+		}
+	}
+
+	if err := s.checkSize(field, size); err != nil {
+		return nil, err
+	}
+
+	data, err := s.readLimited(field, src)
+	if err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("products/%d/%s%s", productID, uuid.New().String(), ext)
+	if err := s.validateImage(field, ext, data); err != nil {
+		return nil, err
+	}
 
-	return s.provider.UploadFile(file, path)
+	return data, nil
 }
 
-func isValidImageExt(ext string) bool {
-	validExts := []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
-	for _, validExt := range validExts {
-		if ext == validExt {
-			return true
+// readLimited reads an upload, failing once it is larger than the limit. The
+// size a client declares can't be trusted, so more than that is never read.
+func (s *UploadService) readLimited(field string, src io.Reader) ([]byte, error) {
+	if s.maxFileSize > 0 {
+		src = io.LimitReader(src, s.maxFileSize+1)
+	}
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSize(field, int64(len(data))); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// validateImage checks data is an image within the dimension limits, in the
+// format its extension claims
+func (s *UploadService) validateImage(field, ext string, data []byte) error {
+	ext = strings.ToLower(ext)
+	format, ok := imageExtFormats[ext]
+	if !ok {
+		return &UploadValidationError{
+			Field:   field,
+			Code:    UploadUnsupportedType,
+			Message: fmt.Sprintf("invalid file type: %s", ext),
+		}
+	}
+
+	info, err := imaging.Inspect(data, s.imageLimits)
+	switch {
+	case errors.Is(err, imaging.ErrImageTooLarge):
+		return &UploadValidationError{
+			Field:   field,
+			Code:    UploadDimensionsTooLarge,
+			Message: fmt.Sprintf("%v; at most %dx%d and %d pixels are allowed", err, s.imageLimits.MaxWidth, s.imageLimits.MaxHeight, s.imageLimits.MaxPixels),
+		}
+	case errors.Is(err, imaging.ErrUnsupportedImage):
+		return &UploadValidationError{Field: field, Code: UploadUnsupportedType, Message: err.Error()}
+	case err != nil:
+		return err
+	}
+
+	if info.Format != format {
+		return &UploadValidationError{
+			Field:   field,
+			Code:    UploadExtensionMismatch,
+			Message: fmt.Sprintf("file has a %s extension but contains a %s image", ext, info.Format),
+		}
+	}
+
+	return nil
+}
+
+// checkSize rejects empty uploads and uploads over the configured limit
+func (s *UploadService) checkSize(field string, size int64) error {
+	if size == 0 {
+		return &UploadValidationError{Field: field, Code: UploadEmptyFile, Message: "file is empty"}
+	}
+	if s.maxFileSize > 0 && size > s.maxFileSize {
+		return &UploadValidationError{
+			Field:   field,
+			Code:    UploadFileTooLarge,
+			Message: fmt.Sprintf("file exceeds the maximum size of %d bytes", s.maxFileSize),
 		}
 	}
 
-	return false
+	return nil
 }
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Allocation strategies for picking the warehouses an order ships from
+const (
+	// AllocationPriority ships each line from the highest priority warehouse
+	// that can fill it, splitting only when none can
+	AllocationPriority = "priority"
+
+	// AllocationClosest is like AllocationPriority with warehouses ordered by
+	// distance to the shipping location
+	AllocationClosest = "closest"
+
+	// AllocationSplit takes what each warehouse has in priority order,
+	// splitting lines freely
+	AllocationSplit = "split"
+)
+
+var ErrInsufficientWarehouseStock = errors.New("insufficient stock at warehouse")
+
+// addStock applies a movement to the stock of a product at a warehouse,
+// keeping the product's total in step, and records it in the ledger
+func addStock(tx *gorm.DB, movement *models.StockMovement) error {
+	warehouseID, productID, delta := movement.WarehouseID, movement.ProductID, movement.Quantity
+	if delta == 0 {
+		return nil
+	}
+
+	if delta > 0 {
+		level := models.WarehouseStock{WarehouseID: warehouseID, ProductID: productID, Quantity: delta}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "warehouse_id"}, {Name: "product_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"quantity":   gorm.Expr("warehouse_stocks.quantity + EXCLUDED.quantity"),
+				"updated_at": time.Now(),
+			}),
+		}).Create(&level).Error; err != nil {
+			return err
+		}
+	} else {
+		result := tx.Model(&models.WarehouseStock{}).
+			Where("warehouse_id = ? AND product_id = ? AND quantity >= ?", warehouseID, productID, -delta).
+			Update("quantity", gorm.Expr("quantity - ?", -delta))
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrInsufficientWarehouseStock
+		}
+	}
+
+	if err := tx.Unscoped().Model(&models.Product{}).
+		Where("id = ?", productID).
+		Update("stock", gorm.Expr("stock + ?", delta)).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(movement).Error
+}
+
+// addDefaultStock applies a movement at the default warehouse, for stock given
+// on the product itself
+func addDefaultStock(tx *gorm.DB, movement *models.StockMovement) error {
+	if movement.Quantity == 0 {
+		return nil
+	}
+
+	var warehouse models.Warehouse
+	if err := tx.Where("is_default = ?", true).First(&warehouse).Error; err != nil {
+		return errors.New("no default warehouse to hold the product's stock")
+	}
+
+	movement.WarehouseID = warehouse.ID
+	return addStock(tx, movement)
+}
+
+type shippingLocation struct {
+	latitude  float64
+	longitude float64
+}
+
+// allocateStock picks the warehouses each order line ships from, takes the
+// units from their stock and records the sales in the ledger. lines are the
+// units of each product per order line, as returned by stockRequirements. The
+// product totals must already have been taken by the caller.
+func allocateStock(tx *gorm.DB, strategy string, shipTo *shippingLocation, lines []map[uint]int, sale models.StockMovement) ([][]models.OrderItemAllocation, error) {
+	var productIDs []uint
+	for _, line := range lines {
+		for productID := range line {
+			if !slices.Contains(productIDs, productID) {
+				productIDs = append(productIDs, productID)
+			}
+		}
+	}
+
+	allocations := make([][]models.OrderItemAllocation, len(lines))
+	if len(productIDs) == 0 {
+		return allocations, nil
+	}
+
+	var warehouses []models.Warehouse
+	if err := tx.Order("priority, id").Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+
+	if strategy == AllocationClosest && shipTo != nil {
+		sort.SliceStable(warehouses, func(i, j int) bool {
+			return warehouseDistance(&warehouses[i], shipTo) < warehouseDistance(&warehouses[j], shipTo)
+		})
+	}
+
+	// Lock the stock levels in a consistent order so concurrent orders can't
+	// allocate the same units
+	var levels []models.WarehouseStock
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id IN ?", productIDs).
+		Order("product_id, warehouse_id").
+		Find(&levels).Error; err != nil {
+		return nil, err
+	}
+
+	stock := make(map[uint]map[uint]int)
+	for i := range levels {
+		if stock[levels[i].ProductID] == nil {
+			stock[levels[i].ProductID] = make(map[uint]int)
+		}
+		stock[levels[i].ProductID][levels[i].WarehouseID] = levels[i].Quantity
+	}
+
+	taken := make(map[[2]uint]int)
+	for i, line := range lines {
+		lineProducts := make([]uint, 0, len(line))
+		for productID := range line {
+			lineProducts = append(lineProducts, productID)
+		}
+		slices.Sort(lineProducts)
+
+		for _, productID := range lineProducts {
+			picked, err := pickWarehouses(strategy, warehouses, stock[productID], line[productID])
+			if err != nil {
+				return nil, fmt.Errorf("%w for product %d", err, productID)
+			}
+
+			for _, allocation := range picked {
+				allocation.ProductID = productID
+				stock[productID][allocation.WarehouseID] -= allocation.Quantity
+				taken[[2]uint{allocation.WarehouseID, productID}] += allocation.Quantity
+				allocations[i] = append(allocations[i], allocation)
+			}
+		}
+	}
+
+	movements := make([]models.StockMovement, 0, len(taken))
+	for key, quantity := range taken {
+		if err := tx.Model(&models.WarehouseStock{}).
+			Where("warehouse_id = ? AND product_id = ?", key[0], key[1]).
+			Update("quantity", gorm.Expr("quantity - ?", quantity)).Error; err != nil {
+			return nil, err
+		}
+
+		movement := sale
+		movement.WarehouseID = key[0]
+		movement.ProductID = key[1]
+		movement.Quantity = -quantity
+		movements = append(movements, movement)
+	}
+
+	if len(movements) > 0 {
+		if err := tx.Create(&movements).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return allocations, nil
+}
+
+// pickWarehouses returns where to take quantity units from, given the stock at
+// each warehouse and the warehouses in the order they should be tried
+func pickWarehouses(strategy string, warehouses []models.Warehouse, stock map[uint]int, quantity int) ([]models.OrderItemAllocation, error) {
+	if strategy != AllocationSplit {
+		for i := range warehouses {
+			if stock[warehouses[i].ID] >= quantity {
+				return []models.OrderItemAllocation{{WarehouseID: warehouses[i].ID, Quantity: quantity}}, nil
+			}
+		}
+	}
+
+	var picked []models.OrderItemAllocation
+	remaining := quantity
+	for i := range warehouses {
+		if remaining == 0 {
+			break
+		}
+
+		if n := min(stock[warehouses[i].ID], remaining); n > 0 {
+			picked = append(picked, models.OrderItemAllocation{WarehouseID: warehouses[i].ID, Quantity: n})
+			remaining -= n
+		}
+	}
+
+	if remaining > 0 {
+		return nil, ErrInsufficientWarehouseStock
+	}
+
+	return picked, nil
+}
+
+// warehouseDistance is the great-circle distance in kilometres from a
+// warehouse to the shipping location. Warehouses without a location sort last.
+func warehouseDistance(warehouse *models.Warehouse, to *shippingLocation) float64 {
+	if warehouse.Latitude == nil || warehouse.Longitude == nil {
+		return math.Inf(1)
+	}
+
+	const earthRadius = 6371.0
+	lat1 := *warehouse.Latitude * math.Pi / 180
+	lat2 := to.latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (to.longitude - *warehouse.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func convertToWarehouseResponse(warehouse *models.Warehouse) dto.WarehouseResponse {
+	return dto.WarehouseResponse{
+		ID:        warehouse.ID,
+		Code:      warehouse.Code,
+		Name:      warehouse.Name,
+		Latitude:  warehouse.Latitude,
+		Longitude: warehouse.Longitude,
+		Priority:  warehouse.Priority,
+		IsDefault: warehouse.IsDefault,
+		CreatedAt: warehouse.CreatedAt,
+		UpdatedAt: warehouse.UpdatedAt,
+	}
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+	"github.com/joefazee/learning-go-shop/internal/events"
+	"github.com/joefazee/learning-go-shop/internal/models"
+	"github.com/joefazee/learning-go-shop/internal/notifications"
+	"github.com/joefazee/learning-go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWarehouseNotFound      = errors.New("warehouse not found")
+	ErrWarehouseNotEmpty      = errors.New("warehouse still holds stock")
+	ErrDefaultWarehouse       = errors.New("the default warehouse cannot be deleted or unset, make another warehouse the default first")
+	ErrBundleStock            = errors.New("bundles have no stock of their own, adjust their components instead")
+	ErrWarehouseCodeInUse     = errors.New("warehouse code is already in use")
+	ErrInvalidStockTransfer   = errors.New("a positive quantity must be transferred between two different warehouses")
+	ErrWarehouseShippedOrders = errors.New("warehouse has shipped orders and cannot be deleted")
+	ErrInvalidStockReason     = errors.New("stock adjustments must be a restock, adjustment, return or damage")
+	ErrStockReasonSign        = errors.New("restocks and returns must add stock and damage must remove it")
+)
+
+var _ WarehouseServiceInterface = (*WarehouseService)(nil)
+
+type WarehouseService struct {
+	db             *gorm.DB
+	config         *config.Config
+	eventPublisher events.Publisher
+}
+
+func NewWarehouseService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher) *WarehouseService {
+	return &WarehouseService{
+		db:             db,
+		config:         config,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (s *WarehouseService) GetWarehouses() ([]dto.WarehouseResponse, error) {
+	var warehouses []models.Warehouse
+	if err := s.db.Order("priority, id").Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.WarehouseResponse, len(warehouses))
+	for i := range warehouses {
+		response[i] = convertToWarehouseResponse(&warehouses[i])
+	}
+
+	return response, nil
+}
+
+func (s *WarehouseService) CreateWarehouse(req *dto.WarehouseRequest) (*dto.WarehouseResponse, error) {
+	warehouse := models.Warehouse{}
+	applyWarehouseRequest(&warehouse, req)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkCode(tx, warehouse.Code, 0); err != nil {
+			return err
+		}
+
+		if warehouse.IsDefault {
+			if err := tx.Model(&models.Warehouse{}).Where("is_default = ?", true).Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&warehouse).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertToWarehouseResponse(&warehouse)
+	return &response, nil
+}
+
+func (s *WarehouseService) UpdateWarehouse(id uint, req *dto.WarehouseRequest) (*dto.WarehouseResponse, error) {
+	var warehouse models.Warehouse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&warehouse, id).Error; err != nil {
+			return ErrWarehouseNotFound
+		}
+
+		if warehouse.IsDefault && !req.IsDefault {
+			return ErrDefaultWarehouse
+		}
+
+		applyWarehouseRequest(&warehouse, req)
+		if err := s.checkCode(tx, warehouse.Code, warehouse.ID); err != nil {
+			return err
+		}
+
+		if warehouse.IsDefault {
+			if err := tx.Model(&models.Warehouse{}).Where("is_default = ? AND id <> ?", true, id).Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(&warehouse).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertToWarehouseResponse(&warehouse)
+	return &response, nil
+}
+
+// DeleteWarehouse removes an empty warehouse. Warehouses that shipped orders
+// are kept for the order history.
+func (s *WarehouseService) DeleteWarehouse(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var warehouse models.Warehouse
+		if err := tx.First(&warehouse, id).Error; err != nil {
+			return ErrWarehouseNotFound
+		}
+
+		if warehouse.IsDefault {
+			return ErrDefaultWarehouse
+		}
+
+		var stocked int64
+		if err := tx.Model(&models.WarehouseStock{}).
+			Where("warehouse_id = ? AND quantity > 0", id).
+			Count(&stocked).Error; err != nil {
+			return err
+		}
+
+		if stocked > 0 {
+			return ErrWarehouseNotEmpty
+		}
+
+		var shipped int64
+		if err := tx.Model(&models.OrderItemAllocation{}).Where("warehouse_id = ?", id).Count(&shipped).Error; err != nil {
+			return err
+		}
+
+		if shipped > 0 {
+			return ErrWarehouseShippedOrders
+		}
+
+		if err := tx.Where("warehouse_id = ?", id).Delete(&models.WarehouseStock{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&warehouse).Error
+	})
+}
+
+// GetProductStock returns the stock of a product at every warehouse
+func (s *WarehouseService) GetProductStock(productID uint) ([]dto.WarehouseStockResponse, error) {
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	var warehouses []models.Warehouse
+	if err := s.db.Order("priority, id").Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+
+	var levels []models.WarehouseStock
+	if err := s.db.Where("product_id = ?", productID).Find(&levels).Error; err != nil {
+		return nil, err
+	}
+
+	byWarehouse := make(map[uint]*models.WarehouseStock, len(levels))
+	for i := range levels {
+		byWarehouse[levels[i].WarehouseID] = &levels[i]
+	}
+
+	response := make([]dto.WarehouseStockResponse, len(warehouses))
+	for i := range warehouses {
+		response[i] = dto.WarehouseStockResponse{
+			WarehouseID:   warehouses[i].ID,
+			WarehouseCode: warehouses[i].Code,
+			WarehouseName: warehouses[i].Name,
+		}
+
+		if level, ok := byWarehouse[warehouses[i].ID]; ok {
+			response[i].Quantity = level.Quantity
+			response[i].UpdatedAt = level.UpdatedAt
+		}
+	}
+
+	return response, nil
+}
+
+// AdjustStock adds units to, or with a negative quantity removes units from,
+// a product's stock at a warehouse and records the movement in the ledger.
+// Units added go to the product's backorders first. Staff are alerted when
+// the adjustment takes the product below its low-stock threshold, and
+// subscribers when it brings the product back in stock. The reason must agree
+// with the direction of the change so the ledger can be reconciled by reason:
+// restocks and returns add units, damage removes them, and adjustments do
+// either.
+func (s *WarehouseService) AdjustStock(actorID, productID uint, req *dto.AdjustStockRequest) ([]dto.WarehouseStockResponse, error) {
+	reason := models.StockMovementReason(req.Reason)
+	switch reason {
+	case models.StockMovementRestock, models.StockMovementReturn:
+		if req.Quantity <= 0 {
+			return nil, ErrStockReasonSign
+		}
+	case models.StockMovementDamage:
+		if req.Quantity >= 0 {
+			return nil, ErrStockReasonSign
+		}
+	case models.StockMovementAdjustment:
+	default:
+		return nil, ErrInvalidStockReason
+	}
+
+	var lowStock []notifications.LowStockPayload
+	var restocked []notifications.BackInStockPayload
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := stockedProduct(tx, productID); err != nil {
+			return err
+		}
+
+		if err := tx.First(&models.Warehouse{}, req.WarehouseID).Error; err != nil {
+			return ErrWarehouseNotFound
+		}
+
+		if err := addStock(tx, &models.StockMovement{
+			ProductID:   productID,
+			WarehouseID: req.WarehouseID,
+			Quantity:    req.Quantity,
+			Reason:      reason,
+			ActorID:     optionalActor(actorID),
+			Reference:   req.Reference,
+		}); err != nil {
+			return err
+		}
+
+		filled, err := fillBackorders(tx, productID, req.WarehouseID, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		delta := req.Quantity - filled
+		alerts, err := lowStockAlerts(tx, s.config.Stock.LowStockThreshold, map[uint]int{productID: delta})
+		if err != nil {
+			return err
+		}
+
+		lowStock = alerts
+
+		restocked, err = backInStock(tx, productID, delta, s.config.BackInStock.BatchSize)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	publishLowStock(s.eventPublisher, lowStock)
+	publishBackInStock(s.eventPublisher, restocked)
+
+	return s.GetProductStock(productID)
+}
+
+// TransferStock moves units of a product between warehouses. The product's
+// total stock is unchanged; the ledger records a transfer out and in.
+func (s *WarehouseService) TransferStock(actorID, productID uint, req *dto.TransferStockRequest) ([]dto.WarehouseStockResponse, error) {
+	if req.FromWarehouseID == req.ToWarehouseID || req.Quantity < 1 {
+		return nil, ErrInvalidStockTransfer
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := stockedProduct(tx, productID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Warehouse{}).
+			Where("id IN ?", []uint{req.FromWarehouseID, req.ToWarehouseID}).
+			Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count != 2 {
+			return ErrWarehouseNotFound
+		}
+
+		transfer := models.StockMovement{
+			ProductID: productID,
+			Reason:    models.StockMovementTransfer,
+			ActorID:   optionalActor(actorID),
+			Reference: req.Reference,
+		}
+
+		out := transfer
+		out.WarehouseID = req.FromWarehouseID
+		out.Quantity = -req.Quantity
+		if err := addStock(tx, &out); err != nil {
+			return err
+		}
+
+		in := transfer
+		in.WarehouseID = req.ToWarehouseID
+		in.Quantity = req.Quantity
+		return addStock(tx, &in)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProductStock(productID)
+}
+
+// GetStockMovements returns a page of a product's stock ledger, newest first
+func (s *WarehouseService) GetStockMovements(productID uint, page, limit int) ([]dto.StockMovementResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 20
+	}
+
+	query := s.db.Model(&models.StockMovement{}).Where("product_id = ?", productID)
+
+	var total int64
+	query.Count(&total)
+
+	var movements []models.StockMovement
+	if err := query.Preload("Warehouse").Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&movements).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.StockMovementResponse, len(movements))
+	for i := range movements {
+		response[i] = convertToStockMovementResponse(&movements[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+// ReconcileStock reports stock levels that differ from the sum of their
+// ledger movements
+func (s *WarehouseService) ReconcileStock() (*dto.StockReconciliationResponse, error) {
+	return reconcileStock(s.db)
+}
+
+func (s *WarehouseService) checkCode(tx *gorm.DB, code string, exceptID uint) error {
+	var count int64
+	if err := tx.Model(&models.Warehouse{}).
+		Where("code = ? AND id <> ?", code, exceptID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ErrWarehouseCodeInUse
+	}
+
+	return nil
+}
+
+// stockedProduct checks that a product exists and holds stock of its own
+func stockedProduct(tx *gorm.DB, productID uint) error {
+	var product models.Product
+	if err := tx.First(&product, productID).Error; err != nil {
+		return ErrProductNotFound
+	}
+
+	if product.IsBundle {
+		return ErrBundleStock
+	}
+
+	return nil
+}
+
+func applyWarehouseRequest(warehouse *models.Warehouse, req *dto.WarehouseRequest) {
+	warehouse.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	warehouse.Name = req.Name
+	warehouse.Latitude = req.Latitude
+	warehouse.Longitude = req.Longitude
+	warehouse.Priority = req.Priority
+	warehouse.IsDefault = req.IsDefault
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/joefazee/learning-go-shop/internal/config"
+	"github.com/joefazee/learning-go-shop/internal/dto"
+)
+
+func TestAdjustStockReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		reason   string
+		quantity int
+		wantErr  error
+	}{
+		{name: "restock adding stock", reason: "restock", quantity: 5},
+		{name: "restock removing stock", reason: "restock", quantity: -5, wantErr: ErrStockReasonSign},
+		{name: "return adding stock", reason: "return", quantity: 1},
+		{name: "return removing stock", reason: "return", quantity: -1, wantErr: ErrStockReasonSign},
+		{name: "damage removing stock", reason: "damage", quantity: -2},
+		{name: "damage adding stock", reason: "damage", quantity: 2, wantErr: ErrStockReasonSign},
+		{name: "adjustment adding stock", reason: "adjustment", quantity: 3},
+		{name: "adjustment removing stock", reason: "adjustment", quantity: -3},
+		{name: "unknown reason", reason: "shrinkage", quantity: -1, wantErr: ErrInvalidStockReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			s := NewWarehouseService(db, &config.Config{}, nil)
+
+			// An accepted reason goes on to look up the product, which is
+			// missing here
+			wantErr := tt.wantErr
+			if wantErr == nil {
+				wantErr = ErrProductNotFound
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			}
+
+			_, err := s.AdjustStock(1, 1, &dto.AdjustStockRequest{
+				WarehouseID: 1,
+				Quantity:    tt.quantity,
+				Reason:      tt.reason,
+			})
+			if !errors.Is(err, wantErr) {
+				t.Errorf("AdjustStock() error = %v, want %v", err, wantErr)
+			}
+		})
+	}
+}
@@ -7,4 +7,6 @@ const (
 	UserEmailKey  ContextKey = "user_email"
 	UserRoleKey   ContextKey = "user_role"
 	GinContextKey ContextKey = "gin_context"
+	CurrencyKey   ContextKey = "currency"
+	LocaleKey     ContextKey = "locale"
 )
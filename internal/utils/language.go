@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AcceptedLanguages returns the language tags of an Accept-Language header,
+// most preferred first. The wildcard and tags with q=0 are left out.
+func AcceptedLanguages(header string) []string {
+	type weightedTag struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+
+		if weight <= 0 {
+			continue
+		}
+
+		tags = append(tags, weightedTag{tag: tag, weight: weight})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].weight > tags[j].weight
+	})
+
+	languages := make([]string, len(tags))
+	for i := range tags {
+		languages[i] = tags[i].tag
+	}
+
+	return languages
+}
@@ -58,6 +58,16 @@ func BadRequestResponse(c *gin.Context, message string, err error) {
 	ErrorResponse(c, http.StatusBadRequest, message, err)
 }
 
+// ValidationErrorResponse rejects a request with details of what was invalid
+func ValidationErrorResponse(c *gin.Context, message string, err error, details interface{}) {
+	c.JSON(http.StatusBadRequest, Response{
+		Success: false,
+		Message: message,
+		Data:    details,
+		Error:   err.Error(),
+	})
+}
+
 func UnauthorizedResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusUnauthorized, message, nil)
 }
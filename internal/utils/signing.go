@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignValue returns the hex encoded HMAC-SHA256 of value
+func SignValue(secret, value string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks in constant time that signature was produced by SignValue
+func VerifySignature(secret, value, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(value))
+	return hmac.Equal(mac.Sum(nil), expected)
+}